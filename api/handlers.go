@@ -1,7 +1,9 @@
 package api
 
 import (
+	"bytes"
 	"encoding/json"
+	"io"
 	"net/http"
 
 	"github.com/yourusername/pasta-factory/pkg/pasta"
@@ -13,6 +15,7 @@ type OrderRequest struct {
 	WeightGrams   int    `json:"weight_grams"`
 	PizzaType     string `json:"pizza_type,omitempty"`
 	PizzaSizeInch int    `json:"pizza_size_inch,omitempty"`
+	ClientOrderID string `json:"client_order_id,omitempty"`
 }
 
 type OrderResponse struct {
@@ -24,24 +27,79 @@ type OrderResponse struct {
 	PizzaType     string  `json:"pizza_type,omitempty"`
 	PizzaSizeInch int     `json:"pizza_size_inch,omitempty"`
 	PizzaPrice    float64 `json:"pizza_price,omitempty"`
+	ClientOrderID string  `json:"client_order_id,omitempty"`
 }
 
+// CreateOrder handles POST /orders. When the request carries an
+// Idempotency-Key header, the response is recorded and replayed on retry:
+// the same key with the same body returns the original response, while the
+// same key with a different body is rejected with 409. Requests without the
+// header are processed directly, with no replay behavior.
 func CreateOrder(w http.ResponseWriter, r *http.Request) {
+	key := r.Header.Get("Idempotency-Key")
+	if key == "" {
+		createOrder(w, r)
+		return
+	}
+
+	bodyBytes, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	r.Body = io.NopCloser(bytes.NewReader(bodyBytes))
+	bodyHash := hashBody(bodyBytes)
+
+	unlock := lockIdempotencyKey(key)
+	defer unlock()
+
+	if priorHash, priorResp, ok := idempotency.Load(key); ok {
+		if priorHash != bodyHash {
+			http.Error(w, "Idempotency-Key already used with a different request body", http.StatusConflict)
+			return
+		}
+		replayResponse(w, priorResp)
+		return
+	}
+
+	rc := newResponseCapture()
+	createOrder(rc, r)
+	resp := rc.result()
+	idempotency.Save(key, bodyHash, resp)
+	scheduleIdempotencyLockEviction(key)
+	replayResponse(w, resp)
+}
+
+func createOrder(w http.ResponseWriter, r *http.Request) {
 	var req OrderRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		http.Error(w, "Invalid request body", http.StatusBadRequest)
 		return
 	}
 
-	resp := OrderResponse{}
+	if req.ClientOrderID != "" && !store.reserveClientOrderID(req.ClientOrderID) {
+		http.Error(w, "client_order_id has already been used", http.StatusConflict)
+		return
+	}
+
+	resp := OrderResponse{ClientOrderID: req.ClientOrderID}
 	totalPrice := 0.0
 
+	// pastaOrder is held back from the store until the whole combined
+	// request validates, so a pasta leg that succeeds but a pizza leg that
+	// fails never becomes a permanently reachable, silently-billed order.
+	var pastaOrder *pasta.Order
 	if req.PastaType != "" {
-		pastaOrder, err := pasta.NewOrder(pasta.PastaType(req.PastaType), req.WeightGrams)
+		var err error
+		pastaOrder, err = pasta.NewOrder(pasta.PastaType(req.PastaType), req.WeightGrams)
 		if err != nil {
+			if req.ClientOrderID != "" {
+				store.releaseClientOrderID(req.ClientOrderID)
+			}
 			http.Error(w, err.Error(), http.StatusBadRequest)
 			return
 		}
+		pastaOrder.ClientOrderID = req.ClientOrderID
 
 		pastaPrice := pastaOrder.CalculatePrice()
 		totalPrice += pastaPrice
@@ -50,33 +108,53 @@ func CreateOrder(w http.ResponseWriter, r *http.Request) {
 		resp.PastaType = string(pastaOrder.PastaType)
 		resp.WeightGrams = pastaOrder.WeightGrams
 		resp.Price = pastaPrice
-		resp.Status = pastaOrder.Status
+		resp.Status = string(pastaOrder.Status())
 	}
 
 	if req.PizzaType != "" {
 		pizzaOrder, err := pizza.NewOrder(pizza.PizzaType(req.PizzaType), req.PizzaSizeInch)
 		if err != nil {
+			// The pasta leg above, if any, already went through NewOrder and
+			// published its own events, but the combined request as a whole
+			// is invalid. Fail it out of the state machine instead of
+			// leaving it live, and never let it reach the store.
+			if pastaOrder != nil {
+				pastaOrder.Fail("system", "rejected: pizza leg of combined order failed validation")
+			}
+			if req.ClientOrderID != "" {
+				store.releaseClientOrderID(req.ClientOrderID)
+			}
 			http.Error(w, err.Error(), http.StatusBadRequest)
 			return
 		}
+		pizzaOrder.ClientOrderID = req.ClientOrderID
 
 		pizzaPrice := pizzaOrder.CalculatePrice()
 		totalPrice += pizzaPrice
 
 		if resp.OrderID == "" {
 			resp.OrderID = pizzaOrder.ID
-			resp.Status = pizzaOrder.Status
+			resp.Status = string(pizzaOrder.Status())
 		}
 		resp.PizzaType = string(pizzaOrder.PizzaType)
 		resp.PizzaSizeInch = pizzaOrder.SizeInch
 		resp.PizzaPrice = pizzaPrice
+
+		store.putPizza(pizzaOrder)
 	}
 
 	if resp.OrderID == "" {
+		if req.ClientOrderID != "" {
+			store.releaseClientOrderID(req.ClientOrderID)
+		}
 		http.Error(w, "Order must include at least pasta or pizza", http.StatusBadRequest)
 		return
 	}
 
+	if pastaOrder != nil {
+		store.putPasta(pastaOrder)
+	}
+
 	if resp.PastaType != "" && resp.PizzaType != "" {
 		resp.Price = totalPrice
 	}
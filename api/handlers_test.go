@@ -252,6 +252,7 @@ func TestInvalidPastaOrder(t *testing.T) {
         t.Errorf("expected error message %q, got %q", expectedError, string(body))
     }
 }
+
 // TestNullPizzaFieldsIgnored verifies that if the JSON payload has null values for pizza fields,
 // they are treated as omitted, and the order is processed as a valid pasta-only order.
 func TestNullPizzaFieldsIgnored(t *testing.T) {
@@ -324,4 +325,38 @@ func TestInvalidCombinedOrder(t *testing.T) {
     if string(body) != expectedError {
         t.Errorf("expected error message %q, got %q", expectedError, string(body))
     }
+}
+
+// TestCombinedOrderPizzaFailureDoesNotLeavePastaOrderLive tests that when the
+// pasta leg of a combined order succeeds but the pizza leg fails validation,
+// the already-created pasta order is never stored: it must not appear in
+// resp.OrderID and must not be reachable afterward through the store.
+func TestCombinedOrderPizzaFailureDoesNotLeavePastaOrderLive(t *testing.T) {
+    reqBody, err := json.Marshal(map[string]interface{}{
+        "pasta_type":      "penne",
+        "weight_grams":    317,
+        "pizza_type":      "margherita",
+        "pizza_size_inch": -1,
+    })
+    if err != nil {
+        t.Fatalf("failed to marshal input: %v", err)
+    }
+    req := httptest.NewRequest("POST", "/order", bytes.NewReader(reqBody))
+    w := httptest.NewRecorder()
+
+    CreateOrder(w, req)
+
+    resp := w.Result()
+    body, _ := ioutil.ReadAll(resp.Body)
+    if resp.StatusCode != http.StatusBadRequest {
+        t.Fatalf("expected status %d, got %d, body: %s", http.StatusBadRequest, resp.StatusCode, string(body))
+    }
+
+    store.mu.RLock()
+    defer store.mu.RUnlock()
+    for _, o := range store.pasta {
+        if o.WeightGrams == 317 {
+            t.Errorf("expected the pasta leg to never reach the store, found order %s with status %s", o.ID, o.Status())
+        }
+    }
 }
\ No newline at end of file
@@ -0,0 +1,148 @@
+package api
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const defaultIdempotencyTTL = 24 * time.Hour
+
+// IdempotentResponse is the recorded outcome of a request handled under an
+// Idempotency-Key, replayed verbatim when the same key is seen again.
+type IdempotentResponse struct {
+	StatusCode  int
+	ContentType string
+	Body        []byte
+}
+
+// IdempotencyStore persists the outcome of idempotent requests keyed by
+// Idempotency-Key, together with a hash of the request body they were
+// recorded against, for a bounded TTL. Implementations must be safe for
+// concurrent use. inMemoryIdempotencyStore below is the default, suitable
+// for a single instance; a Redis-backed store can satisfy the same
+// interface for multi-instance deployments.
+type IdempotencyStore interface {
+	// Load returns the recorded response for key and the body hash it was
+	// saved against, if a non-expired entry exists.
+	Load(key string) (bodyHash string, resp IdempotentResponse, found bool)
+	// Save records resp for key against bodyHash, replacing any prior entry.
+	Save(key, bodyHash string, resp IdempotentResponse)
+}
+
+type idempotencyEntry struct {
+	bodyHash  string
+	resp      IdempotentResponse
+	expiresAt time.Time
+}
+
+// inMemoryIdempotencyStore is a process-local IdempotencyStore backed by a
+// map. Expired entries are evicted lazily on Load.
+type inMemoryIdempotencyStore struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	entries map[string]idempotencyEntry
+}
+
+func newInMemoryIdempotencyStore(ttl time.Duration) *inMemoryIdempotencyStore {
+	return &inMemoryIdempotencyStore{
+		ttl:     ttl,
+		entries: make(map[string]idempotencyEntry),
+	}
+}
+
+func (s *inMemoryIdempotencyStore) Load(key string) (string, IdempotentResponse, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entry, ok := s.entries[key]
+	if !ok || time.Now().After(entry.expiresAt) {
+		delete(s.entries, key)
+		return "", IdempotentResponse{}, false
+	}
+	return entry.bodyHash, entry.resp, true
+}
+
+func (s *inMemoryIdempotencyStore) Save(key, bodyHash string, resp IdempotentResponse) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.entries[key] = idempotencyEntry{
+		bodyHash:  bodyHash,
+		resp:      resp,
+		expiresAt: time.Now().Add(s.ttl),
+	}
+}
+
+// idempotency is the package-level IdempotencyStore used by CreateOrder.
+var idempotency IdempotencyStore = newInMemoryIdempotencyStore(defaultIdempotencyTTL)
+
+// idempotencyLocks holds one mutex per recently used Idempotency-Key so that
+// concurrent retries of the same key serialize instead of racing to create
+// duplicate orders. Unlike inMemoryIdempotencyStore.entries, this map has no
+// lazy eviction path of its own (nothing ever reads a stale key to trigger
+// one), so scheduleIdempotencyLockEviction removes each entry once its
+// idempotency record has expired, keeping the map bounded under retry
+// traffic instead of growing for the life of the process.
+var idempotencyLocks sync.Map // map[string]*sync.Mutex
+
+func lockIdempotencyKey(key string) (unlock func()) {
+	lockIface, _ := idempotencyLocks.LoadOrStore(key, &sync.Mutex{})
+	lock := lockIface.(*sync.Mutex)
+	lock.Lock()
+	return lock.Unlock
+}
+
+// scheduleIdempotencyLockEviction removes key's entry from idempotencyLocks
+// once its idempotency record is due to have expired. A retry within the TTL
+// still takes the key's lock before idempotency.Load replays the recorded
+// response; a request after eviction finds no record and gets a fresh mutex
+// from lockIdempotencyKey.
+func scheduleIdempotencyLockEviction(key string) {
+	scheduleIdempotencyLockEvictionAfter(key, defaultIdempotencyTTL)
+}
+
+func scheduleIdempotencyLockEvictionAfter(key string, ttl time.Duration) {
+	time.AfterFunc(ttl, func() {
+		idempotencyLocks.Delete(key)
+	})
+}
+
+func hashBody(body []byte) string {
+	sum := sha256.Sum256(body)
+	return hex.EncodeToString(sum[:])
+}
+
+// responseCapture is an http.ResponseWriter that buffers what was written to
+// it so the result can be recorded in an IdempotencyStore before being
+// flushed to the real client.
+type responseCapture struct {
+	header     http.Header
+	statusCode int
+	body       bytes.Buffer
+}
+
+func newResponseCapture() *responseCapture {
+	return &responseCapture{header: make(http.Header), statusCode: http.StatusOK}
+}
+
+func (rc *responseCapture) Header() http.Header { return rc.header }
+
+func (rc *responseCapture) Write(b []byte) (int, error) { return rc.body.Write(b) }
+
+func (rc *responseCapture) WriteHeader(statusCode int) { rc.statusCode = statusCode }
+
+func (rc *responseCapture) result() IdempotentResponse {
+	return IdempotentResponse{StatusCode: rc.statusCode, ContentType: rc.header.Get("Content-Type"), Body: rc.body.Bytes()}
+}
+
+func replayResponse(w http.ResponseWriter, resp IdempotentResponse) {
+	if resp.ContentType != "" {
+		w.Header().Set("Content-Type", resp.ContentType)
+	}
+	w.WriteHeader(resp.StatusCode)
+	w.Write(resp.Body)
+}
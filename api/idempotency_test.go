@@ -0,0 +1,158 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestCreateOrderIdempotencyReplaysSameBody(t *testing.T) {
+	payload := map[string]interface{}{"pasta_type": "spaghetti", "weight_grams": 300}
+	reqBody, _ := json.Marshal(payload)
+	key := "idem-key-replay"
+
+	req1 := httptest.NewRequest("POST", "/orders", bytes.NewReader(reqBody))
+	req1.Header.Set("Idempotency-Key", key)
+	w1 := httptest.NewRecorder()
+	CreateOrder(w1, req1)
+
+	req2 := httptest.NewRequest("POST", "/orders", bytes.NewReader(reqBody))
+	req2.Header.Set("Idempotency-Key", key)
+	w2 := httptest.NewRecorder()
+	CreateOrder(w2, req2)
+
+	if w1.Code != http.StatusOK || w2.Code != http.StatusOK {
+		t.Fatalf("expected both requests to succeed, got %d and %d", w1.Code, w2.Code)
+	}
+
+	var resp1, resp2 OrderResponse
+	if err := json.Unmarshal(w1.Body.Bytes(), &resp1); err != nil {
+		t.Fatalf("failed to unmarshal first response: %v", err)
+	}
+	if err := json.Unmarshal(w2.Body.Bytes(), &resp2); err != nil {
+		t.Fatalf("failed to unmarshal second response: %v", err)
+	}
+	if resp1.OrderID != resp2.OrderID {
+		t.Errorf("expected replayed response to reuse order_id %q, got %q", resp1.OrderID, resp2.OrderID)
+	}
+}
+
+func TestCreateOrderIdempotencyConflictOnDifferentBody(t *testing.T) {
+	key := "idem-key-conflict"
+
+	body1, _ := json.Marshal(map[string]interface{}{"pasta_type": "spaghetti", "weight_grams": 300})
+	req1 := httptest.NewRequest("POST", "/orders", bytes.NewReader(body1))
+	req1.Header.Set("Idempotency-Key", key)
+	w1 := httptest.NewRecorder()
+	CreateOrder(w1, req1)
+	if w1.Code != http.StatusOK {
+		t.Fatalf("expected first request to succeed, got %d, body: %s", w1.Code, w1.Body.String())
+	}
+
+	body2, _ := json.Marshal(map[string]interface{}{"pasta_type": "penne", "weight_grams": 400})
+	req2 := httptest.NewRequest("POST", "/orders", bytes.NewReader(body2))
+	req2.Header.Set("Idempotency-Key", key)
+	w2 := httptest.NewRecorder()
+	CreateOrder(w2, req2)
+
+	if w2.Code != http.StatusConflict {
+		t.Errorf("expected status %d for reused key with a different body, got %d", http.StatusConflict, w2.Code)
+	}
+}
+
+func TestCreateOrderWithoutIdempotencyKeyDoesNotDeduplicate(t *testing.T) {
+	// Two distinct requests with no Idempotency-Key must each be processed
+	// independently: a second, different order is created rather than the
+	// first response being replayed.
+	body1, _ := json.Marshal(map[string]interface{}{"pasta_type": "spaghetti", "weight_grams": 300})
+	req1 := httptest.NewRequest("POST", "/orders", bytes.NewReader(body1))
+	w1 := httptest.NewRecorder()
+	CreateOrder(w1, req1)
+
+	body2, _ := json.Marshal(map[string]interface{}{"pasta_type": "penne", "weight_grams": 450})
+	req2 := httptest.NewRequest("POST", "/orders", bytes.NewReader(body2))
+	w2 := httptest.NewRecorder()
+	CreateOrder(w2, req2)
+
+	var resp1, resp2 OrderResponse
+	json.Unmarshal(w1.Body.Bytes(), &resp1)
+	json.Unmarshal(w2.Body.Bytes(), &resp2)
+
+	if resp1.PastaType != "spaghetti" || resp2.PastaType != "penne" {
+		t.Errorf("expected each request to be processed on its own terms, got %q and %q", resp1.PastaType, resp2.PastaType)
+	}
+	if resp2.WeightGrams != 450 {
+		t.Errorf("expected second order's weight to reflect its own request, got %d", resp2.WeightGrams)
+	}
+}
+
+func TestCreateOrderConcurrentDuplicatesReturnSameOrder(t *testing.T) {
+	reqBody, _ := json.Marshal(map[string]interface{}{"pasta_type": "spaghetti", "weight_grams": 300})
+	key := "idem-key-concurrent"
+
+	const n = 10
+	responses := make([]OrderResponse, n)
+	var wg sync.WaitGroup
+	wg.Add(n)
+	for i := 0; i < n; i++ {
+		go func(i int) {
+			defer wg.Done()
+			req := httptest.NewRequest("POST", "/orders", bytes.NewReader(reqBody))
+			req.Header.Set("Idempotency-Key", key)
+			w := httptest.NewRecorder()
+			CreateOrder(w, req)
+			json.Unmarshal(w.Body.Bytes(), &responses[i])
+		}(i)
+	}
+	wg.Wait()
+
+	first := responses[0].OrderID
+	if first == "" {
+		t.Fatal("expected a non-empty order_id")
+	}
+	for i, resp := range responses {
+		if resp.OrderID != first {
+			t.Errorf("response %d: expected order_id %q, got %q", i, first, resp.OrderID)
+		}
+	}
+}
+
+func TestIdempotencyLockIsEvictedAfterTTL(t *testing.T) {
+	key := "idem-key-eviction"
+	idempotencyLocks.LoadOrStore(key, &sync.Mutex{})
+
+	scheduleIdempotencyLockEvictionAfter(key, 20*time.Millisecond)
+
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		if _, ok := idempotencyLocks.Load(key); !ok {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("timed out waiting for the idempotency lock to be evicted")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestCreateOrderClientOrderIDMustBeUnique(t *testing.T) {
+	body1, _ := json.Marshal(map[string]interface{}{"pasta_type": "spaghetti", "weight_grams": 300, "client_order_id": "co-unique-1"})
+	req1 := httptest.NewRequest("POST", "/orders", bytes.NewReader(body1))
+	w1 := httptest.NewRecorder()
+	CreateOrder(w1, req1)
+	if w1.Code != http.StatusOK {
+		t.Fatalf("expected first request to succeed, got %d, body: %s", w1.Code, w1.Body.String())
+	}
+
+	body2, _ := json.Marshal(map[string]interface{}{"pasta_type": "penne", "weight_grams": 400, "client_order_id": "co-unique-1"})
+	req2 := httptest.NewRequest("POST", "/orders", bytes.NewReader(body2))
+	w2 := httptest.NewRecorder()
+	CreateOrder(w2, req2)
+	if w2.Code != http.StatusConflict {
+		t.Errorf("expected status %d for a reused client_order_id, got %d", http.StatusConflict, w2.Code)
+	}
+}
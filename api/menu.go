@@ -0,0 +1,30 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/yourusername/pasta-factory/pkg/menu"
+)
+
+// GetMenu returns the currently active menu, so clients can discover valid
+// SKUs, pricing, and availability windows without hardcoding them.
+func GetMenu(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, menu.Default().Current())
+}
+
+// ReloadMenu forces an immediate reload of the menu from its backing file,
+// for operators who don't want to wait for the file watcher to notice a
+// config change. It 501s if the configured provider doesn't support manual
+// reloads (e.g. the built-in static menu).
+func ReloadMenu(w http.ResponseWriter, r *http.Request) {
+	reloadable, ok := menu.Default().(interface{ Reload() error })
+	if !ok {
+		http.Error(w, "menu provider does not support manual reload", http.StatusNotImplemented)
+		return
+	}
+	if err := reloadable.Reload(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	writeJSON(w, menu.Default().Current())
+}
@@ -0,0 +1,42 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/yourusername/pasta-factory/pkg/menu"
+)
+
+func TestGetMenuReturnsConfiguredItems(t *testing.T) {
+	req := httptest.NewRequest("GET", "/menu", nil)
+	w := httptest.NewRecorder()
+	GetMenu(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d", http.StatusOK, w.Code)
+	}
+
+	var m menu.Menu
+	if err := json.Unmarshal(w.Body.Bytes(), &m); err != nil {
+		t.Fatalf("failed to unmarshal menu response: %v", err)
+	}
+	if _, ok := m.Lookup("pasta", "fettuccine"); !ok {
+		t.Error("expected the default menu to include fettuccine")
+	}
+}
+
+func TestReloadMenuReturns501WhenProviderDoesNotSupportReload(t *testing.T) {
+	previous := menu.Default()
+	menu.SetDefault(menu.NewStaticProvider(previous.Current()))
+	defer menu.SetDefault(previous)
+
+	req := httptest.NewRequest("POST", "/admin/menu/reload", nil)
+	w := httptest.NewRecorder()
+	ReloadMenu(w, req)
+
+	if w.Code != http.StatusNotImplemented {
+		t.Errorf("expected status %d, got %d", http.StatusNotImplemented, w.Code)
+	}
+}
@@ -0,0 +1,63 @@
+package api
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestMetricsHandlerExposesOrderMetricsAfterTraffic(t *testing.T) {
+	reqBody, _ := json.Marshal(map[string]interface{}{"pasta_type": "fettuccine", "weight_grams": 500})
+	req := httptest.NewRequest("POST", "/orders", bytes.NewReader(reqBody))
+	w := httptest.NewRecorder()
+	CreateOrder(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected order creation to succeed, got %d, body: %s", w.Code, w.Body.String())
+	}
+
+	metricsReq := httptest.NewRequest("GET", "/metrics", nil)
+	metricsW := httptest.NewRecorder()
+	MetricsHandler().ServeHTTP(metricsW, metricsReq)
+
+	if metricsW.Code != http.StatusOK {
+		t.Fatalf("expected status %d from /metrics, got %d", http.StatusOK, metricsW.Code)
+	}
+
+	body := metricsW.Body.String()
+	if !strings.Contains(body, `orders_created_total{type="pasta",variant="fettuccine"}`) {
+		t.Errorf("expected orders_created_total series for pasta/fettuccine, got:\n%s", body)
+	}
+	if !strings.Contains(body, `order_price_dollars_bucket{type="pasta"`) {
+		t.Errorf("expected order_price_dollars histogram buckets for pasta, got:\n%s", body)
+	}
+}
+
+func TestMetricsHandlerGzipsWhenAccepted(t *testing.T) {
+	req := httptest.NewRequest("GET", "/metrics", nil)
+	req.Header.Set("Accept-Encoding", "gzip")
+	w := httptest.NewRecorder()
+	MetricsHandler().ServeHTTP(w, req)
+
+	if w.Header().Get("Content-Encoding") != "gzip" {
+		t.Fatalf("expected Content-Encoding: gzip, got %q", w.Header().Get("Content-Encoding"))
+	}
+
+	gz, err := gzip.NewReader(w.Body)
+	if err != nil {
+		t.Fatalf("expected a valid gzip body: %v", err)
+	}
+	defer gz.Close()
+
+	decoded, err := io.ReadAll(gz)
+	if err != nil {
+		t.Fatalf("failed to decompress body: %v", err)
+	}
+	if !strings.Contains(string(decoded), "orders_created_total") {
+		t.Error("expected decompressed body to contain orders_created_total metric")
+	}
+}
@@ -0,0 +1,115 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/yourusername/pasta-factory/pkg/orderstate"
+)
+
+// OrderDetail is the representation returned by GET /orders/{id}.
+type OrderDetail struct {
+	OrderID string             `json:"order_id"`
+	Kind    string             `json:"kind"` // "pasta" or "pizza"
+	Status  orderstate.Status  `json:"status"`
+	History []orderstate.Entry `json:"history"`
+}
+
+// TransitionRequest drives POST /orders/{id}/transition.
+type TransitionRequest struct {
+	Action string `json:"action"`
+	Actor  string `json:"actor"`
+	Reason string `json:"reason,omitempty"`
+}
+
+// GetOrder returns the current status and audit history of an order.
+func GetOrder(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+
+	if o, ok := store.getPasta(id); ok {
+		writeJSON(w, OrderDetail{OrderID: o.ID, Kind: "pasta", Status: o.Status(), History: o.History()})
+		return
+	}
+	if o, ok := store.getPizza(id); ok {
+		writeJSON(w, OrderDetail{OrderID: o.ID, Kind: "pizza", Status: o.Status(), History: o.History()})
+		return
+	}
+	http.Error(w, "order not found", http.StatusNotFound)
+}
+
+// TransitionOrder applies a lifecycle action to an order and returns its new
+// status and audit history.
+func TransitionOrder(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+
+	var req TransitionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	if req.Actor == "" {
+		http.Error(w, "actor is required", http.StatusBadRequest)
+		return
+	}
+
+	var err error
+	var detail OrderDetail
+
+	if o, ok := store.getPasta(id); ok {
+		err = applyAction(o, req)
+		detail = OrderDetail{OrderID: o.ID, Kind: "pasta", Status: o.Status(), History: o.History()}
+	} else if o, ok := store.getPizza(id); ok {
+		err = applyAction(o, req)
+		detail = OrderDetail{OrderID: o.ID, Kind: "pizza", Status: o.Status(), History: o.History()}
+	} else {
+		http.Error(w, "order not found", http.StatusNotFound)
+		return
+	}
+
+	if err != nil {
+		status := http.StatusBadRequest
+		if errors.Is(err, orderstate.ErrIllegalTransition) {
+			status = http.StatusConflict
+		}
+		http.Error(w, err.Error(), status)
+		return
+	}
+
+	writeJSON(w, detail)
+}
+
+// transitionable is satisfied by both pasta.Order and pizza.Order.
+type transitionable interface {
+	Accept(actor string) error
+	StartCooking(actor string) error
+	MarkReady(actor string) error
+	Deliver(actor string) error
+	Cancel(actor, reason string) error
+	Fail(actor, reason string) error
+}
+
+func applyAction(o transitionable, req TransitionRequest) error {
+	switch req.Action {
+	case "accept":
+		return o.Accept(req.Actor)
+	case "start_cooking":
+		return o.StartCooking(req.Actor)
+	case "mark_ready":
+		return o.MarkReady(req.Actor)
+	case "deliver":
+		return o.Deliver(req.Actor)
+	case "cancel":
+		return o.Cancel(req.Actor, req.Reason)
+	case "fail":
+		return o.Fail(req.Actor, req.Reason)
+	default:
+		return errors.New("unknown transition action: " + req.Action)
+	}
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(v)
+}
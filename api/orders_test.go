@@ -0,0 +1,135 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+)
+
+// newOrder places a valid pasta order through CreateOrder and returns its ID.
+func newOrder(t *testing.T) string {
+	t.Helper()
+	reqBody, _ := json.Marshal(map[string]interface{}{"pasta_type": "spaghetti", "weight_grams": 300})
+	req := httptest.NewRequest("POST", "/orders", bytes.NewReader(reqBody))
+	w := httptest.NewRecorder()
+	CreateOrder(w, req)
+
+	var resp OrderResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to unmarshal create response: %v", err)
+	}
+	return resp.OrderID
+}
+
+func withIDRoute(handler http.HandlerFunc) *mux.Router {
+	r := mux.NewRouter()
+	r.HandleFunc("/orders/{id}", handler)
+	r.HandleFunc("/orders/{id}/transition", handler)
+	return r
+}
+
+func TestGetOrderReturnsStatusAndHistory(t *testing.T) {
+	id := newOrder(t)
+
+	req := httptest.NewRequest("GET", "/orders/"+id, nil)
+	w := httptest.NewRecorder()
+	withIDRoute(GetOrder).ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d", http.StatusOK, w.Code)
+	}
+
+	var detail OrderDetail
+	if err := json.Unmarshal(w.Body.Bytes(), &detail); err != nil {
+		t.Fatalf("failed to unmarshal response: %v", err)
+	}
+	if detail.OrderID != id {
+		t.Errorf("expected order_id %q, got %q", id, detail.OrderID)
+	}
+	if detail.Status != "pending" {
+		t.Errorf("expected status 'pending', got %q", detail.Status)
+	}
+	if len(detail.History) != 0 {
+		t.Errorf("expected empty history for a new order, got %d entries", len(detail.History))
+	}
+}
+
+func TestGetOrderNotFound(t *testing.T) {
+	req := httptest.NewRequest("GET", "/orders/does-not-exist", nil)
+	w := httptest.NewRecorder()
+	withIDRoute(GetOrder).ServeHTTP(w, req)
+
+	if w.Code != http.StatusNotFound {
+		t.Errorf("expected status %d, got %d", http.StatusNotFound, w.Code)
+	}
+}
+
+func TestTransitionOrderHappyPath(t *testing.T) {
+	id := newOrder(t)
+
+	body, _ := json.Marshal(TransitionRequest{Action: "accept", Actor: "kitchen"})
+	req := httptest.NewRequest("POST", "/orders/"+id+"/transition", bytes.NewReader(body))
+	w := httptest.NewRecorder()
+	withIDRoute(TransitionOrder).ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d, body: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+
+	var detail OrderDetail
+	if err := json.Unmarshal(w.Body.Bytes(), &detail); err != nil {
+		t.Fatalf("failed to unmarshal response: %v", err)
+	}
+	if detail.Status != "accepted" {
+		t.Errorf("expected status 'accepted', got %q", detail.Status)
+	}
+	if len(detail.History) != 1 {
+		t.Fatalf("expected 1 history entry, got %d", len(detail.History))
+	}
+	if detail.History[0].Actor != "kitchen" {
+		t.Errorf("expected actor 'kitchen', got %q", detail.History[0].Actor)
+	}
+}
+
+func TestTransitionOrderIllegalMoveReturnsConflict(t *testing.T) {
+	id := newOrder(t)
+
+	body, _ := json.Marshal(TransitionRequest{Action: "deliver", Actor: "kitchen"})
+	req := httptest.NewRequest("POST", "/orders/"+id+"/transition", bytes.NewReader(body))
+	w := httptest.NewRecorder()
+	withIDRoute(TransitionOrder).ServeHTTP(w, req)
+
+	if w.Code != http.StatusConflict {
+		t.Errorf("expected status %d, got %d, body: %s", http.StatusConflict, w.Code, w.Body.String())
+	}
+}
+
+func TestTransitionOrderRequiresActor(t *testing.T) {
+	id := newOrder(t)
+
+	body, _ := json.Marshal(TransitionRequest{Action: "accept"})
+	req := httptest.NewRequest("POST", "/orders/"+id+"/transition", bytes.NewReader(body))
+	w := httptest.NewRecorder()
+	withIDRoute(TransitionOrder).ServeHTTP(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected status %d, got %d", http.StatusBadRequest, w.Code)
+	}
+}
+
+func TestTransitionOrderUnknownAction(t *testing.T) {
+	id := newOrder(t)
+
+	body, _ := json.Marshal(TransitionRequest{Action: "teleport", Actor: "kitchen"})
+	req := httptest.NewRequest("POST", "/orders/"+id+"/transition", bytes.NewReader(body))
+	w := httptest.NewRecorder()
+	withIDRoute(TransitionOrder).ServeHTTP(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected status %d, got %d", http.StatusBadRequest, w.Code)
+	}
+}
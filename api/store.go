@@ -0,0 +1,75 @@
+package api
+
+import (
+	"sync"
+
+	"github.com/yourusername/pasta-factory/pkg/pasta"
+	"github.com/yourusername/pasta-factory/pkg/pizza"
+)
+
+// orderStore keeps the orders created through CreateOrder around in memory
+// so that later requests can look them up by ID and drive their lifecycle.
+// It is a package-level singleton because handlers are plain functions
+// registered directly with the router, not methods on a struct.
+type orderStore struct {
+	mu             sync.RWMutex
+	pasta          map[string]*pasta.Order
+	pizza          map[string]*pizza.Order
+	clientOrderIDs map[string]bool
+}
+
+func newOrderStore() *orderStore {
+	return &orderStore{
+		pasta:          make(map[string]*pasta.Order),
+		pizza:          make(map[string]*pizza.Order),
+		clientOrderIDs: make(map[string]bool),
+	}
+}
+
+var store = newOrderStore()
+
+func (s *orderStore) putPasta(o *pasta.Order) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.pasta[o.ID] = o
+}
+
+func (s *orderStore) putPizza(o *pizza.Order) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.pizza[o.ID] = o
+}
+
+func (s *orderStore) getPasta(id string) (*pasta.Order, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	o, ok := s.pasta[id]
+	return o, ok
+}
+
+func (s *orderStore) getPizza(id string) (*pizza.Order, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	o, ok := s.pizza[id]
+	return o, ok
+}
+
+// reserveClientOrderID claims id for a new order, returning false if it has
+// already been used by another order.
+func (s *orderStore) reserveClientOrderID(id string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.clientOrderIDs[id] {
+		return false
+	}
+	s.clientOrderIDs[id] = true
+	return true
+}
+
+// releaseClientOrderID frees id after a reservation that did not end up
+// producing an order, so it can be reused.
+func (s *orderStore) releaseClientOrderID(id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.clientOrderIDs, id)
+}
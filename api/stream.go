@@ -0,0 +1,69 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/yourusername/pasta-factory/pkg/events"
+)
+
+// heartbeatInterval is how often idle stream connections send a keepalive
+// so that intermediate proxies don't time them out.
+const heartbeatInterval = 15 * time.Second
+
+func parseFilter(r *http.Request) events.Filter {
+	return events.Filter{
+		Kind:   r.URL.Query().Get("type"),
+		Status: r.URL.Query().Get("status"),
+	}
+}
+
+// StreamOrders handles GET /orders/stream, a Server-Sent Events feed of
+// order lifecycle events. The optional ?type= and ?status= query params
+// narrow it to a single order kind and/or status. If this subscriber falls
+// behind and the bus has to drop its oldest buffered events, the next frame
+// sent is a "dropped" event reporting how many were lost.
+func StreamOrders(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+
+	ch := events.DefaultBus.Subscribe(parseFilter(r))
+	defer events.DefaultBus.Unsubscribe(ch)
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	heartbeat := time.NewTicker(heartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case e, ok := <-ch:
+			if !ok {
+				return
+			}
+			if dropped := events.DefaultBus.Dropped(ch); dropped > 0 {
+				fmt.Fprintf(w, "event: dropped\ndata: {\"x-dropped-count\":%d}\n\n", dropped)
+			}
+			payload, err := json.Marshal(e)
+			if err != nil {
+				continue
+			}
+			fmt.Fprintf(w, "event: %s\ndata: %s\n\n", e.Type, payload)
+			flusher.Flush()
+		case <-heartbeat.C:
+			fmt.Fprint(w, ": heartbeat\n\n")
+			flusher.Flush()
+		case <-r.Context().Done():
+			return
+		}
+	}
+}
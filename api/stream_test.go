@@ -0,0 +1,57 @@
+package api
+
+import (
+	"bufio"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestStreamOrdersDeliversOrderCreatedEvent(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(StreamOrders))
+	defer srv.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, "GET", srv.URL+"/orders/stream?type=pasta", nil)
+	if err != nil {
+		t.Fatalf("failed to build request: %v", err)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("failed to connect to stream: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected status %d, got %d", http.StatusOK, resp.StatusCode)
+	}
+	if ct := resp.Header.Get("Content-Type"); ct != "text/event-stream" {
+		t.Errorf("expected Content-Type 'text/event-stream', got %q", ct)
+	}
+
+	reqBody := `{"pasta_type": "spaghetti", "weight_grams": 300}`
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		createReq := httptest.NewRequest("POST", "/orders", strings.NewReader(reqBody))
+		CreateOrder(httptest.NewRecorder(), createReq)
+	}()
+
+	scanner := bufio.NewScanner(resp.Body)
+	found := false
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.Contains(line, "order_created") {
+			found = true
+			break
+		}
+	}
+	if !found {
+		t.Error("expected to see an order_created event on the stream")
+	}
+}
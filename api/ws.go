@@ -0,0 +1,61 @@
+package api
+
+import (
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/yourusername/pasta-factory/pkg/events"
+)
+
+var upgrader = websocket.Upgrader{
+	// The API and its dashboards are expected to share an origin; this keeps
+	// the demo working without a CORS story to configure.
+	CheckOrigin: func(r *http.Request) bool { return true },
+}
+
+type droppedFrame struct {
+	Type          string `json:"type"`
+	XDroppedCount int64  `json:"x-dropped-count"`
+}
+
+// StreamOrdersWS handles GET /orders/ws, the WebSocket equivalent of
+// StreamOrders for clients that prefer a persistent socket over SSE. It
+// supports the same ?type= and ?status= filters and the same drop-oldest
+// backpressure behavior.
+func StreamOrdersWS(w http.ResponseWriter, r *http.Request) {
+	conn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		log.Printf("orders/ws: upgrade failed: %v", err)
+		return
+	}
+	defer conn.Close()
+
+	ch := events.DefaultBus.Subscribe(parseFilter(r))
+	defer events.DefaultBus.Unsubscribe(ch)
+
+	heartbeat := time.NewTicker(heartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case e, ok := <-ch:
+			if !ok {
+				return
+			}
+			if dropped := events.DefaultBus.Dropped(ch); dropped > 0 {
+				if err := conn.WriteJSON(droppedFrame{Type: "dropped", XDroppedCount: dropped}); err != nil {
+					return
+				}
+			}
+			if err := conn.WriteJSON(e); err != nil {
+				return
+			}
+		case <-heartbeat.C:
+			if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(5*time.Second)); err != nil {
+				return
+			}
+		}
+	}
+}
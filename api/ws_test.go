@@ -0,0 +1,127 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/yourusername/pasta-factory/pkg/events"
+)
+
+func dialOrdersWS(t *testing.T, srv *httptest.Server, query string) *websocket.Conn {
+	t.Helper()
+	wsURL := "ws" + strings.TrimPrefix(srv.URL, "http") + "/orders/ws" + query
+	conn, _, err := websocket.DefaultDialer.Dial(wsURL, nil)
+	if err != nil {
+		t.Fatalf("failed to dial %s: %v", wsURL, err)
+	}
+	return conn
+}
+
+func TestStreamOrdersWSDeliversOrderCreatedEvent(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(StreamOrdersWS))
+	defer srv.Close()
+
+	conn := dialOrdersWS(t, srv, "?type=pasta")
+	defer conn.Close()
+
+	reqBody := `{"pasta_type": "spaghetti", "weight_grams": 300}`
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		createReq := httptest.NewRequest("POST", "/orders", strings.NewReader(reqBody))
+		CreateOrder(httptest.NewRecorder(), createReq)
+	}()
+
+	conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	for {
+		var e events.Event
+		if err := conn.ReadJSON(&e); err != nil {
+			t.Fatalf("failed reading from websocket: %v", err)
+		}
+		if e.Type == events.OrderCreated && e.OrderKind == "pasta" {
+			return
+		}
+	}
+}
+
+func TestStreamOrdersWSFiltersByTypeAndStatus(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(StreamOrdersWS))
+	defer srv.Close()
+
+	conn := dialOrdersWS(t, srv, "?type=pizza&status=cancelled")
+	defer conn.Close()
+
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		// An unrelated pasta order must not show up on a pizza-only stream.
+		pastaReq := httptest.NewRequest("POST", "/orders", strings.NewReader(`{"pasta_type": "spaghetti", "weight_grams": 300}`))
+		CreateOrder(httptest.NewRecorder(), pastaReq)
+
+		time.Sleep(20 * time.Millisecond)
+		// A pizza order that's merely created, not cancelled, must not show
+		// up either: the stream is filtered to status=cancelled.
+		pizzaReq := httptest.NewRequest("POST", "/orders", strings.NewReader(`{"pizza_type": "margherita", "pizza_size_inch": 12}`))
+		w := httptest.NewRecorder()
+		CreateOrder(w, pizzaReq)
+
+		var resp OrderResponse
+		if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+			t.Errorf("failed to unmarshal pizza order response: %v", err)
+			return
+		}
+
+		time.Sleep(20 * time.Millisecond)
+		pizzaOrder, ok := store.getPizza(resp.OrderID)
+		if !ok {
+			t.Errorf("expected pizza order %q to be in the store", resp.OrderID)
+			return
+		}
+		if err := pizzaOrder.Cancel("customer", "changed my mind"); err != nil {
+			t.Errorf("unexpected error cancelling pizza order: %v", err)
+		}
+	}()
+
+	conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	var e events.Event
+	if err := conn.ReadJSON(&e); err != nil {
+		t.Fatalf("failed reading from websocket: %v", err)
+	}
+	if e.Type != events.OrderCancelled || e.OrderKind != "pizza" {
+		t.Errorf("expected the first frame delivered to be a pizza OrderCancelled event, got %+v", e)
+	}
+}
+
+func TestStreamOrdersWSSendsDroppedFrameWhenSubscriberFallsBehind(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(StreamOrdersWS))
+	defer srv.Close()
+
+	conn := dialOrdersWS(t, srv, "?type=pasta")
+	defer conn.Close()
+
+	// Publish far more pasta events than a subscriber's buffer can hold
+	// without reading any of them, so the bus starts dropping the oldest
+	// queued events before StreamOrdersWS ever gets a chance to relay them.
+	for i := 0; i < 500; i++ {
+		events.DefaultBus.Publish(events.Event{Type: events.OrderStateChanged, OrderKind: "pasta"})
+	}
+
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	for {
+		var raw map[string]interface{}
+		if err := conn.ReadJSON(&raw); err != nil {
+			t.Fatalf("expected a dropped frame before the read deadline, got error: %v", err)
+		}
+		if raw["type"] != "dropped" {
+			continue
+		}
+		count, ok := raw["x-dropped-count"].(float64)
+		if !ok || count <= 0 {
+			t.Errorf("expected a positive x-dropped-count, got %v", raw["x-dropped-count"])
+		}
+		return
+	}
+}
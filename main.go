@@ -3,15 +3,34 @@ package main
 import (
 	"log"
 	"net/http"
+	"os"
 
 	"github.com/gorilla/mux"
 	"github.com/yourusername/pasta-factory/api"
+	"github.com/yourusername/pasta-factory/pkg/menu"
+	"github.com/yourusername/pasta-factory/pkg/metrics"
 )
 
 func main() {
+	if path := os.Getenv("MENU_FILE"); path != "" {
+		provider, err := menu.NewFileProvider(path)
+		if err != nil {
+			log.Fatalf("loading menu file %q: %v", path, err)
+		}
+		menu.SetDefault(provider)
+	}
+
 	r := mux.NewRouter()
+	r.Use(metrics.Middleware)
 
 	r.HandleFunc("/orders", api.CreateOrder).Methods("POST")
+	r.HandleFunc("/orders/stream", api.StreamOrders).Methods("GET")
+	r.HandleFunc("/orders/ws", api.StreamOrdersWS).Methods("GET")
+	r.HandleFunc("/orders/{id}", api.GetOrder).Methods("GET")
+	r.HandleFunc("/orders/{id}/transition", api.TransitionOrder).Methods("POST")
+	r.HandleFunc("/menu", api.GetMenu).Methods("GET")
+	r.HandleFunc("/admin/menu/reload", api.ReloadMenu).Methods("POST")
+	r.Handle("/metrics", api.MetricsHandler()).Methods("GET")
 
 	log.Printf("Starting server on :8080")
 	if err := http.ListenAndServe(":8080", r); err != nil {
@@ -0,0 +1,149 @@
+// Package events provides a small in-process pub/sub bus so that kitchen
+// displays and dashboards can be notified of order activity in real time
+// instead of polling the API.
+package events
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Type identifies the kind of thing that happened to an order.
+type Type string
+
+const (
+	OrderCreated      Type = "order_created"
+	OrderStateChanged Type = "order_state_changed"
+	OrderPriced       Type = "order_priced"
+	OrderCancelled    Type = "order_cancelled"
+)
+
+// Event describes something that happened to an order. Fields that don't
+// apply to a given Type are left zero.
+type Event struct {
+	Type      Type      `json:"type"`
+	OrderID   string    `json:"order_id"`
+	OrderKind string    `json:"order_kind"` // "pasta" or "pizza"
+	Status    string    `json:"status,omitempty"`
+	From      string    `json:"from,omitempty"`
+	Price     float64   `json:"price,omitempty"`
+	Reason    string    `json:"reason,omitempty"`
+	At        time.Time `json:"at"`
+}
+
+// Filter narrows a subscription to events matching the given order kind
+// and/or status. A zero-value Filter matches everything.
+type Filter struct {
+	Kind   string
+	Status string
+}
+
+func (f Filter) matches(e Event) bool {
+	if f.Kind != "" && f.Kind != e.OrderKind {
+		return false
+	}
+	if f.Status != "" && f.Status != e.Status {
+		return false
+	}
+	return true
+}
+
+// subscriberBufferSize bounds how many unread events a slow subscriber can
+// fall behind by before the bus starts dropping its oldest events.
+const subscriberBufferSize = 64
+
+// EventBus lets publishers broadcast Events to any number of subscribers,
+// each with its own filter and bounded buffer.
+type EventBus interface {
+	Publish(e Event)
+	Subscribe(filter Filter) <-chan Event
+	// Unsubscribe stops delivery to a channel returned by Subscribe and
+	// closes it. It is a no-op if ch is not a live subscription.
+	Unsubscribe(ch <-chan Event)
+	// Dropped returns and resets the number of events dropped for ch because
+	// its buffer was full when they were published.
+	Dropped(ch <-chan Event) int64
+}
+
+type subscriber struct {
+	ch      chan Event
+	filter  Filter
+	dropped int64
+}
+
+type bus struct {
+	mu          sync.RWMutex
+	subscribers []*subscriber
+}
+
+// NewBus returns an in-process EventBus.
+func NewBus() EventBus {
+	return &bus{}
+}
+
+func (b *bus) Publish(e Event) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	for _, sub := range b.subscribers {
+		if !sub.filter.matches(e) {
+			continue
+		}
+		select {
+		case sub.ch <- e:
+		default:
+			// Buffer is full: drop the oldest queued event to make room
+			// rather than block the publisher on a slow subscriber.
+			select {
+			case <-sub.ch:
+				atomic.AddInt64(&sub.dropped, 1)
+			default:
+			}
+			select {
+			case sub.ch <- e:
+			default:
+			}
+		}
+	}
+}
+
+func (b *bus) Subscribe(filter Filter) <-chan Event {
+	sub := &subscriber{ch: make(chan Event, subscriberBufferSize), filter: filter}
+
+	b.mu.Lock()
+	b.subscribers = append(b.subscribers, sub)
+	b.mu.Unlock()
+
+	return sub.ch
+}
+
+func (b *bus) Unsubscribe(ch <-chan Event) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for i, sub := range b.subscribers {
+		if sub.ch == ch {
+			b.subscribers = append(b.subscribers[:i], b.subscribers[i+1:]...)
+			close(sub.ch)
+			return
+		}
+	}
+}
+
+func (b *bus) Dropped(ch <-chan Event) int64 {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	for _, sub := range b.subscribers {
+		if sub.ch == ch {
+			return atomic.SwapInt64(&sub.dropped, 0)
+		}
+	}
+	return 0
+}
+
+// DefaultBus is the process-wide EventBus used by the pasta, pizza, and api
+// packages so that order lifecycle events are visible regardless of which
+// package produced them.
+var DefaultBus = NewBus()
@@ -0,0 +1,62 @@
+package events
+
+import (
+	"testing"
+	"time"
+)
+
+func drain(t *testing.T, ch <-chan Event) Event {
+	t.Helper()
+	select {
+	case e := <-ch:
+		return e
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for event")
+		return Event{}
+	}
+}
+
+func TestPublishDeliversToMatchingSubscriber(t *testing.T) {
+	b := NewBus()
+	ch := b.Subscribe(Filter{Kind: "pizza"})
+
+	b.Publish(Event{Type: OrderCreated, OrderID: "1", OrderKind: "pasta"})
+	b.Publish(Event{Type: OrderCreated, OrderID: "2", OrderKind: "pizza"})
+
+	got := drain(t, ch)
+	if got.OrderID != "2" {
+		t.Errorf("expected only the pizza event to be delivered, got order_id %q", got.OrderID)
+	}
+
+	select {
+	case e := <-ch:
+		t.Errorf("expected no further events, got %+v", e)
+	default:
+	}
+}
+
+func TestUnsubscribeClosesChannel(t *testing.T) {
+	b := NewBus()
+	ch := b.Subscribe(Filter{})
+	b.Unsubscribe(ch)
+
+	if _, ok := <-ch; ok {
+		t.Error("expected channel to be closed after Unsubscribe")
+	}
+}
+
+func TestPublishDropsOldestWhenBufferIsFull(t *testing.T) {
+	b := NewBus()
+	ch := b.Subscribe(Filter{})
+
+	for i := 0; i < subscriberBufferSize+5; i++ {
+		b.Publish(Event{Type: OrderCreated, OrderID: string(rune('a' + i%26))})
+	}
+
+	if dropped := b.Dropped(ch); dropped != 5 {
+		t.Errorf("expected 5 dropped events, got %d", dropped)
+	}
+	if dropped := b.Dropped(ch); dropped != 0 {
+		t.Errorf("expected Dropped to reset the counter, got %d on second call", dropped)
+	}
+}
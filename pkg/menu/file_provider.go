@@ -0,0 +1,120 @@
+package menu
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+
+	"github.com/fsnotify/fsnotify"
+	"gopkg.in/yaml.v3"
+)
+
+// FileProvider loads a Menu from a YAML or JSON file and hot-reloads it
+// whenever the file changes on disk. Reloads swap an atomic.Pointer[Menu]
+// so in-flight requests always see a consistent snapshot, never a
+// half-written one.
+type FileProvider struct {
+	path    string
+	current atomic.Pointer[Menu]
+	watcher *fsnotify.Watcher
+	done    chan struct{}
+}
+
+// NewFileProvider loads path once, then starts watching it for changes. The
+// returned FileProvider must be closed with Close to stop the watcher
+// goroutine.
+func NewFileProvider(path string) (*FileProvider, error) {
+	p := &FileProvider{path: path, done: make(chan struct{})}
+	if err := p.Reload(); err != nil {
+		return nil, err
+	}
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, fmt.Errorf("menu: starting file watcher: %w", err)
+	}
+	if err := watcher.Add(filepath.Dir(path)); err != nil {
+		watcher.Close()
+		return nil, fmt.Errorf("menu: watching %s: %w", filepath.Dir(path), err)
+	}
+	p.watcher = watcher
+
+	go p.watch()
+	return p, nil
+}
+
+// Current returns the most recently loaded Menu.
+func (p *FileProvider) Current() *Menu {
+	return p.current.Load()
+}
+
+// Reload re-reads the menu file from disk and swaps it in. On read or parse
+// failure, the previously loaded Menu stays in effect and the error is
+// returned to the caller.
+func (p *FileProvider) Reload() error {
+	m, err := loadFile(p.path)
+	if err != nil {
+		return err
+	}
+	p.current.Store(m)
+	return nil
+}
+
+// Close stops the background watcher goroutine.
+func (p *FileProvider) Close() error {
+	close(p.done)
+	return p.watcher.Close()
+}
+
+func (p *FileProvider) watch() {
+	for {
+		select {
+		case event, ok := <-p.watcher.Events:
+			if !ok {
+				return
+			}
+			if filepath.Clean(event.Name) != filepath.Clean(p.path) {
+				continue
+			}
+			if event.Op&(fsnotify.Write|fsnotify.Create) == 0 {
+				continue
+			}
+			if err := p.Reload(); err != nil {
+				log.Printf("menu: reload of %s failed, keeping previous menu: %v", p.path, err)
+			}
+		case err, ok := <-p.watcher.Errors:
+			if !ok {
+				return
+			}
+			log.Printf("menu: watcher error: %v", err)
+		case <-p.done:
+			return
+		}
+	}
+}
+
+func loadFile(path string) (*Menu, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("menu: reading %s: %w", path, err)
+	}
+
+	var m Menu
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		if err := yaml.Unmarshal(data, &m); err != nil {
+			return nil, fmt.Errorf("menu: parsing %s: %w", path, err)
+		}
+	case ".json":
+		if err := json.Unmarshal(data, &m); err != nil {
+			return nil, fmt.Errorf("menu: parsing %s: %w", path, err)
+		}
+	default:
+		return nil, fmt.Errorf("menu: unsupported file extension for %s", path)
+	}
+	return &m, nil
+}
@@ -0,0 +1,78 @@
+// Package menu loads the factory's sellable items — pasta and pizza SKUs,
+// their unit pricing, premiums, quantity limits, and availability windows —
+// from an external YAML or JSON spec instead of hardcoding them in switch
+// statements scattered across the pasta and pizza packages.
+package menu
+
+import (
+	"fmt"
+	"time"
+)
+
+// Item describes one sellable SKU.
+type Item struct {
+	SKU               string   `yaml:"sku" json:"sku"`
+	Category          string   `yaml:"category" json:"category"`
+	BaseUnit          string   `yaml:"base_unit" json:"base_unit"`
+	UnitPrice         float64  `yaml:"unit_price" json:"unit_price"`
+	PremiumMultiplier float64  `yaml:"premium_multiplier" json:"premium_multiplier"`
+	Min               int      `yaml:"min" json:"min"`
+	Max               int      `yaml:"max" json:"max"`
+	Tags              []string `yaml:"tags,omitempty" json:"tags,omitempty"`
+
+	// AvailableFrom and AvailableUntil restrict the item to a window of the
+	// day, as "HH:MM" in local time (e.g. hawaiian pizza only after 5pm).
+	// Both must be set for the window to apply; either left blank means the
+	// item is always available.
+	AvailableFrom  string `yaml:"available_from,omitempty" json:"available_from,omitempty"`
+	AvailableUntil string `yaml:"available_until,omitempty" json:"available_until,omitempty"`
+}
+
+// AvailableAt reports whether the item may be sold at time t. Windows may
+// wrap past midnight (available_from "22:00", available_until "02:00").
+func (item Item) AvailableAt(t time.Time) bool {
+	if item.AvailableFrom == "" || item.AvailableUntil == "" {
+		return true
+	}
+
+	from, err := minutesSinceMidnight(item.AvailableFrom)
+	if err != nil {
+		return true
+	}
+	until, err := minutesSinceMidnight(item.AvailableUntil)
+	if err != nil {
+		return true
+	}
+
+	now := t.Hour()*60 + t.Minute()
+	if from <= until {
+		return now >= from && now < until
+	}
+	return now >= from || now < until
+}
+
+func minutesSinceMidnight(hhmm string) (int, error) {
+	t, err := time.Parse("15:04", hhmm)
+	if err != nil {
+		return 0, fmt.Errorf("menu: invalid time of day %q: %w", hhmm, err)
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+// Menu is an immutable snapshot of the sellable items. MenuProvider
+// implementations swap in a new *Menu rather than mutating one in place, so
+// callers holding a reference always see a consistent view.
+type Menu struct {
+	Items []Item `yaml:"items" json:"items"`
+}
+
+// Lookup returns the item for the given category ("pasta" or "pizza") and
+// SKU, and whether one was found.
+func (m *Menu) Lookup(category, sku string) (Item, bool) {
+	for _, item := range m.Items {
+		if item.Category == category && item.SKU == sku {
+			return item, true
+		}
+	}
+	return Item{}, false
+}
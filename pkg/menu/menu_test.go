@@ -0,0 +1,138 @@
+package menu
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestLookupFindsItemByCategoryAndSKU(t *testing.T) {
+	m := &Menu{Items: []Item{
+		{SKU: "fettuccine", Category: "pasta", UnitPrice: 0.01, PremiumMultiplier: 1.2},
+	}}
+
+	item, ok := m.Lookup("pasta", "fettuccine")
+	if !ok {
+		t.Fatal("expected to find fettuccine in the pasta category")
+	}
+	if item.PremiumMultiplier != 1.2 {
+		t.Errorf("expected premium multiplier 1.2, got %v", item.PremiumMultiplier)
+	}
+
+	if _, ok := m.Lookup("pizza", "fettuccine"); ok {
+		t.Error("did not expect to find fettuccine in the pizza category")
+	}
+}
+
+func TestItemAvailableAtWithNoWindowIsAlwaysAvailable(t *testing.T) {
+	item := Item{SKU: "margherita"}
+	if !item.AvailableAt(time.Date(2026, 7, 29, 3, 0, 0, 0, time.UTC)) {
+		t.Error("expected an item with no availability window to always be available")
+	}
+}
+
+func TestItemAvailableAtRespectsWindow(t *testing.T) {
+	item := Item{SKU: "hawaiian", AvailableFrom: "17:00", AvailableUntil: "23:59"}
+
+	before := time.Date(2026, 7, 29, 16, 59, 0, 0, time.UTC)
+	if item.AvailableAt(before) {
+		t.Error("expected hawaiian to be unavailable before 17:00")
+	}
+
+	during := time.Date(2026, 7, 29, 18, 0, 0, 0, time.UTC)
+	if !item.AvailableAt(during) {
+		t.Error("expected hawaiian to be available at 18:00")
+	}
+}
+
+func TestItemAvailableAtWrapsPastMidnight(t *testing.T) {
+	item := Item{SKU: "late-night-slice", AvailableFrom: "22:00", AvailableUntil: "02:00"}
+
+	lateNight := time.Date(2026, 7, 29, 23, 30, 0, 0, time.UTC)
+	if !item.AvailableAt(lateNight) {
+		t.Error("expected item to be available late at night within a wrapping window")
+	}
+
+	earlyMorning := time.Date(2026, 7, 29, 1, 30, 0, 0, time.UTC)
+	if !item.AvailableAt(earlyMorning) {
+		t.Error("expected item to be available in the early morning within a wrapping window")
+	}
+
+	midday := time.Date(2026, 7, 29, 12, 0, 0, 0, time.UTC)
+	if item.AvailableAt(midday) {
+		t.Error("expected item to be unavailable at midday outside the wrapping window")
+	}
+}
+
+func TestStaticProviderAlwaysReturnsSameMenu(t *testing.T) {
+	m := &Menu{Items: []Item{{SKU: "penne", Category: "pasta"}}}
+	p := NewStaticProvider(m)
+	if p.Current() != m {
+		t.Error("expected StaticProvider.Current to return the exact Menu it was constructed with")
+	}
+}
+
+func TestFileProviderLoadsAndHotReloads(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "menu.yaml")
+
+	const initial = `
+items:
+  - sku: spaghetti
+    category: pasta
+    base_unit: gram
+    unit_price: 0.01
+    premium_multiplier: 1.0
+    min: 100
+    max: 5000
+`
+	if err := os.WriteFile(path, []byte(initial), 0o644); err != nil {
+		t.Fatalf("failed to write initial menu file: %v", err)
+	}
+
+	p, err := NewFileProvider(path)
+	if err != nil {
+		t.Fatalf("NewFileProvider returned an error: %v", err)
+	}
+	defer p.Close()
+
+	if _, ok := p.Current().Lookup("pasta", "spaghetti"); !ok {
+		t.Fatal("expected spaghetti to be present after initial load")
+	}
+	if _, ok := p.Current().Lookup("pasta", "penne"); ok {
+		t.Fatal("did not expect penne to be present before the reload")
+	}
+
+	const updated = `
+items:
+  - sku: spaghetti
+    category: pasta
+    base_unit: gram
+    unit_price: 0.01
+    premium_multiplier: 1.0
+    min: 100
+    max: 5000
+  - sku: penne
+    category: pasta
+    base_unit: gram
+    unit_price: 0.01
+    premium_multiplier: 1.0
+    min: 100
+    max: 5000
+`
+	if err := os.WriteFile(path, []byte(updated), 0o644); err != nil {
+		t.Fatalf("failed to write updated menu file: %v", err)
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		if _, ok := p.Current().Lookup("pasta", "penne"); ok {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("timed out waiting for the file watcher to pick up the menu change")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
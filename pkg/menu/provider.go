@@ -0,0 +1,60 @@
+package menu
+
+import "sync/atomic"
+
+// MenuProvider supplies the current Menu snapshot. Implementations must be
+// safe for concurrent use, since handlers may call Current while a reload
+// swaps it out from under them.
+type MenuProvider interface {
+	Current() *Menu
+}
+
+// staticProvider serves a fixed Menu that never changes after construction.
+type staticProvider struct {
+	menu *Menu
+}
+
+// NewStaticProvider returns a MenuProvider that always serves m. It's used
+// for tests and as the factory's built-in fallback menu.
+func NewStaticProvider(m *Menu) MenuProvider {
+	return &staticProvider{menu: m}
+}
+
+func (p *staticProvider) Current() *Menu {
+	return p.menu
+}
+
+// defaultMenu mirrors the pricing and quantity limits that pasta and pizza
+// orders used before menu configuration existed. It also lists hawaiian
+// pizza, which those orders rejected as an invalid type, and makes it
+// orderable only from 17:00 until 23:59 local time.
+var defaultMenu = &Menu{
+	Items: []Item{
+		{SKU: "spaghetti", Category: "pasta", BaseUnit: "gram", UnitPrice: 0.01, PremiumMultiplier: 1.0, Min: 100, Max: 5000},
+		{SKU: "penne", Category: "pasta", BaseUnit: "gram", UnitPrice: 0.01, PremiumMultiplier: 1.0, Min: 100, Max: 5000},
+		{SKU: "fettuccine", Category: "pasta", BaseUnit: "gram", UnitPrice: 0.01, PremiumMultiplier: 1.2, Min: 100, Max: 5000},
+		{SKU: "margherita", Category: "pizza", BaseUnit: "inch", UnitPrice: 1.0, PremiumMultiplier: 1.0, Min: 8, Max: 24},
+		{SKU: "pepperoni", Category: "pizza", BaseUnit: "inch", UnitPrice: 1.0, PremiumMultiplier: 1.2, Min: 8, Max: 24},
+		{SKU: "hawaiian", Category: "pizza", BaseUnit: "inch", UnitPrice: 1.0, PremiumMultiplier: 1.3, Min: 8, Max: 24, AvailableFrom: "17:00", AvailableUntil: "23:59"},
+	},
+}
+
+var current atomic.Pointer[MenuProvider]
+
+func init() {
+	var p MenuProvider = NewStaticProvider(defaultMenu)
+	current.Store(&p)
+}
+
+// Default returns the MenuProvider consulted by pasta.NewOrder and
+// pizza.NewOrder. It starts out backed by the factory's built-in pricing;
+// main swaps in a FileProvider with SetDefault when a menu config file is
+// configured.
+func Default() MenuProvider {
+	return *current.Load()
+}
+
+// SetDefault replaces the MenuProvider returned by Default.
+func SetDefault(p MenuProvider) {
+	current.Store(&p)
+}
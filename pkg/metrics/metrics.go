@@ -0,0 +1,148 @@
+// Package metrics defines the Prometheus collectors shared by the pasta,
+// pizza, and api packages, plus an HTTP middleware that records request
+// latency and in-flight request counts for any mux.Router.
+package metrics
+
+import (
+	"bufio"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	// OrdersCreatedTotal counts successfully created orders, by order type
+	// ("pasta"/"pizza") and variant (e.g. "fettuccine", "hawaiian").
+	OrdersCreatedTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "orders_created_total",
+			Help: "Total number of orders successfully created, by order type and variant.",
+		},
+		[]string{"type", "variant"},
+	)
+
+	// OrdersFailedTotal counts rejected order attempts, by order type and a
+	// short machine-readable rejection reason.
+	OrdersFailedTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "orders_failed_total",
+			Help: "Total number of order creation attempts that failed, by order type and reason.",
+		},
+		[]string{"type", "reason"},
+	)
+
+	// OrderPriceDollars tracks the distribution of order prices, by order
+	// type.
+	OrderPriceDollars = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "order_price_dollars",
+			Help:    "Distribution of order prices in dollars, by order type.",
+			Buckets: []float64{1, 5, 10, 20, 35, 50, 75, 100},
+		},
+		[]string{"type"},
+	)
+
+	// HTTPRequestDuration tracks request latency, by matched route, HTTP
+	// method, and response status.
+	HTTPRequestDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "http_request_duration_seconds",
+			Help:    "Latency of HTTP requests, by route, method, and status.",
+			Buckets: prometheus.DefBuckets,
+		},
+		[]string{"route", "method", "status"},
+	)
+
+	// InFlightRequests is the number of HTTP requests currently being
+	// served.
+	InFlightRequests = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "in_flight_requests",
+			Help: "Number of HTTP requests currently being served.",
+		},
+	)
+)
+
+func init() {
+	prometheus.MustRegister(
+		OrdersCreatedTotal,
+		OrdersFailedTotal,
+		OrderPriceDollars,
+		HTTPRequestDuration,
+		InFlightRequests,
+	)
+}
+
+// RecordOrderCreated increments OrdersCreatedTotal and observes priceDollars
+// into OrderPriceDollars for a successfully created order.
+func RecordOrderCreated(orderType, variant string, priceDollars float64) {
+	OrdersCreatedTotal.WithLabelValues(orderType, variant).Inc()
+	OrderPriceDollars.WithLabelValues(orderType).Observe(priceDollars)
+}
+
+// RecordOrderFailed increments OrdersFailedTotal for a rejected order
+// attempt.
+func RecordOrderFailed(orderType, reason string) {
+	OrdersFailedTotal.WithLabelValues(orderType, reason).Inc()
+}
+
+// Middleware wraps an http.Handler to record HTTPRequestDuration and
+// InFlightRequests for every request it serves. Register it with
+// mux.Router.Use so that routeTemplate can label requests by matched route;
+// wrapped around a whole router, it labels them by raw path.
+func Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		InFlightRequests.Inc()
+		defer InFlightRequests.Dec()
+
+		start := time.Now()
+		sw := &statusWriter{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(sw, r)
+
+		HTTPRequestDuration.
+			WithLabelValues(routeTemplate(r), r.Method, strconv.Itoa(sw.status)).
+			Observe(time.Since(start).Seconds())
+	})
+}
+
+// routeTemplate returns the gorilla/mux route template matched for r (e.g.
+// "/orders/{id}"), falling back to the raw path if no route matched.
+func routeTemplate(r *http.Request) string {
+	if route := mux.CurrentRoute(r); route != nil {
+		if tmpl, err := route.GetPathTemplate(); err == nil {
+			return tmpl
+		}
+	}
+	return r.URL.Path
+}
+
+// statusWriter records the status code passed to WriteHeader so it can be
+// reported as a metric label after the handler has run.
+type statusWriter struct {
+	http.ResponseWriter
+	status int
+}
+
+func (w *statusWriter) WriteHeader(status int) {
+	w.status = status
+	w.ResponseWriter.WriteHeader(status)
+}
+
+// Flush and Hijack pass through to the wrapped ResponseWriter so that the
+// SSE and WebSocket order streams keep working behind Middleware.
+func (w *statusWriter) Flush() {
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+func (w *statusWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	if h, ok := w.ResponseWriter.(http.Hijacker); ok {
+		return h.Hijack()
+	}
+	return nil, nil, http.ErrNotSupported
+}
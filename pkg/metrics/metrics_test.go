@@ -0,0 +1,53 @@
+package metrics
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestRecordOrderCreatedIncrementsCountersAndHistogram(t *testing.T) {
+	before := testutil.ToFloat64(OrdersCreatedTotal.WithLabelValues("pasta", "fettuccine"))
+	RecordOrderCreated("pasta", "fettuccine", 12.0)
+	after := testutil.ToFloat64(OrdersCreatedTotal.WithLabelValues("pasta", "fettuccine"))
+
+	if after != before+1 {
+		t.Errorf("expected orders_created_total{type=pasta,variant=fettuccine} to increase by 1, got %v -> %v", before, after)
+	}
+}
+
+func TestRecordOrderFailedIncrementsCounter(t *testing.T) {
+	before := testutil.ToFloat64(OrdersFailedTotal.WithLabelValues("pizza", "invalid_type"))
+	RecordOrderFailed("pizza", "invalid_type")
+	after := testutil.ToFloat64(OrdersFailedTotal.WithLabelValues("pizza", "invalid_type"))
+
+	if after != before+1 {
+		t.Errorf("expected orders_failed_total{type=pizza,reason=invalid_type} to increase by 1, got %v -> %v", before, after)
+	}
+}
+
+func TestMiddlewareRecordsRequestDuration(t *testing.T) {
+	r := mux.NewRouter()
+	r.Use(Middleware)
+	r.HandleFunc("/widgets/{id}", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTeapot)
+	})
+
+	countBefore := testutil.CollectAndCount(HTTPRequestDuration)
+
+	req := httptest.NewRequest("GET", "/widgets/42", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusTeapot {
+		t.Fatalf("expected status %d, got %d", http.StatusTeapot, w.Code)
+	}
+
+	countAfter := testutil.CollectAndCount(HTTPRequestDuration)
+	if countAfter <= countBefore {
+		t.Errorf("expected a new http_request_duration_seconds series to appear, had %d series, now %d", countBefore, countAfter)
+	}
+}
@@ -0,0 +1,18 @@
+//go:build legacy
+
+package orderid
+
+import "time"
+
+// legacyFormat is the layout produced by the pre-orderid generateOrderID
+// helper that both pasta and pizza used to call directly.
+const legacyFormat = "20060102150405"
+
+// ParseLegacy parses an order ID produced by the old bare-timestamp scheme
+// ("20060102150405", with no entropy) into the time it was generated. It
+// only exists to let anyone still holding IDs issued before the switch to
+// New read them back; it is compiled in only when building with the
+// `legacy` build tag.
+func ParseLegacy(s string) (time.Time, error) {
+	return time.Parse(legacyFormat, s)
+}
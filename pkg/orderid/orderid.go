@@ -0,0 +1,122 @@
+// Package orderid generates collision-resistant, lexicographically
+// sortable order identifiers, replacing bare timestamp IDs that collide for
+// any two orders placed within the same second.
+//
+// Each ID is 128 bits, Crockford Base32 encoded into a 26-character string:
+// the first 48 bits are a millisecond Unix timestamp (so IDs sort by
+// creation time) followed by 80 bits of crypto/rand entropy (so IDs created
+// within the same millisecond still don't collide).
+package orderid
+
+import (
+	"crypto/rand"
+	"fmt"
+	"time"
+)
+
+// encoding is the Crockford Base32 alphabet: it omits I, L, O, and U to
+// avoid confusion with 1, 1, 0, and V when an ID is read aloud or typed.
+const encoding = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+// length is the number of characters needed to encode 128 bits, 5 bits at a
+// time: ceil(128/5) = 26.
+const length = 26
+
+// pad is the number of leading zero bits needed so that length*5 bits
+// evenly covers the 128 bits of payload.
+const pad = length*5 - 128
+
+var decoding [256]int8
+
+func init() {
+	for i := range decoding {
+		decoding[i] = -1
+	}
+	for i := 0; i < len(encoding); i++ {
+		decoding[encoding[i]] = int8(i)
+	}
+}
+
+// New returns a new order ID timestamped at the current time.
+func New() string {
+	return newFromTime(time.Now())
+}
+
+func newFromTime(t time.Time) string {
+	var data [16]byte
+	ms := uint64(t.UnixMilli())
+	for i := 0; i < 6; i++ {
+		data[5-i] = byte(ms >> (8 * i))
+	}
+	if _, err := rand.Read(data[6:]); err != nil {
+		// crypto/rand.Read only fails if the system's CSPRNG is broken, which
+		// makes generating a safe ID impossible.
+		panic("orderid: failed to read random entropy: " + err.Error())
+	}
+	return encode(data)
+}
+
+// Parse returns the timestamp embedded in an ID produced by New.
+func Parse(s string) (time.Time, error) {
+	data, err := decode(s)
+	if err != nil {
+		return time.Time{}, err
+	}
+	var ms uint64
+	for i := 0; i < 6; i++ {
+		ms = (ms << 8) | uint64(data[i])
+	}
+	return time.UnixMilli(int64(ms)), nil
+}
+
+func encode(data [16]byte) string {
+	var out [length]byte
+	for i := 0; i < length; i++ {
+		var v byte
+		for b := 0; b < 5; b++ {
+			v = (v << 1) | bitAt(data[:], i*5+b-pad)
+		}
+		out[i] = encoding[v]
+	}
+	return string(out[:])
+}
+
+func decode(s string) ([16]byte, error) {
+	var data [16]byte
+	if len(s) != length {
+		return data, fmt.Errorf("orderid: invalid id %q: want %d characters, got %d", s, length, len(s))
+	}
+
+	var bits [length * 5]byte
+	for i := 0; i < length; i++ {
+		v := decoding[s[i]]
+		if v < 0 {
+			return data, fmt.Errorf("orderid: invalid id %q: bad character %q", s, s[i])
+		}
+		for b := 0; b < 5; b++ {
+			bits[i*5+b] = byte(v>>(4-b)) & 1
+		}
+	}
+
+	for i := 0; i < 128; i++ {
+		bit := bits[i+pad]
+		data[i/8] |= bit << (7 - uint(i%8))
+	}
+	return data, nil
+}
+
+// bitAt returns the bit of data at bitIndex, counting from the most
+// significant bit of data[0]. Indexes before 0 read as zero, which supplies
+// the leading pad bits needed to align 128 bits of payload to a 5-bit
+// boundary.
+func bitAt(data []byte, bitIndex int) byte {
+	if bitIndex < 0 {
+		return 0
+	}
+	byteIndex := bitIndex / 8
+	if byteIndex >= len(data) {
+		return 0
+	}
+	shift := 7 - uint(bitIndex%8)
+	return (data[byteIndex] >> shift) & 1
+}
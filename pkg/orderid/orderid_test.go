@@ -0,0 +1,74 @@
+package orderid
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestNewLength(t *testing.T) {
+	id := New()
+	if len(id) != 26 {
+		t.Errorf("expected a 26 character id, got %d: %q", len(id), id)
+	}
+}
+
+func TestParseRoundTrip(t *testing.T) {
+	before := time.Now()
+	id := New()
+	after := time.Now()
+
+	got, err := Parse(id)
+	if err != nil {
+		t.Fatalf("Parse(%q) returned unexpected error: %v", id, err)
+	}
+
+	if got.Before(before.Truncate(time.Millisecond)) || got.After(after) {
+		t.Errorf("parsed time %v outside of generation window [%v, %v]", got, before, after)
+	}
+}
+
+func TestParseRejectsWrongLength(t *testing.T) {
+	if _, err := Parse("TOOSHORT"); err == nil {
+		t.Error("expected an error for an id of the wrong length, got nil")
+	}
+}
+
+func TestParseRejectsInvalidCharacters(t *testing.T) {
+	// 'I', 'L', 'O', and 'U' are deliberately excluded from the Crockford
+	// Base32 alphabet.
+	bad := "0123456789ABCDEFGHIJKLMNOP"
+	if _, err := Parse(bad); err == nil {
+		t.Error("expected an error for an id containing excluded characters, got nil")
+	}
+}
+
+func TestNewIsUniqueUnderConcurrency(t *testing.T) {
+	const n = 10000
+	ids := make([]string, n)
+	var wg sync.WaitGroup
+	wg.Add(n)
+	for i := 0; i < n; i++ {
+		go func(i int) {
+			defer wg.Done()
+			ids[i] = New()
+		}(i)
+	}
+	wg.Wait()
+
+	seen := make(map[string]bool, n)
+	for _, id := range ids {
+		if seen[id] {
+			t.Fatalf("duplicate id generated: %q", id)
+		}
+		seen[id] = true
+	}
+}
+
+func TestNewIsLexicographicallySortableByTime(t *testing.T) {
+	earlier := newFromTime(time.UnixMilli(1000))
+	later := newFromTime(time.UnixMilli(2000))
+	if !(earlier < later) {
+		t.Errorf("expected id for earlier timestamp to sort before later one, got %q >= %q", earlier, later)
+	}
+}
@@ -0,0 +1,112 @@
+// Package orderstate implements the order lifecycle state machine shared by
+// pasta and pizza orders: pending -> accepted -> cooking -> ready ->
+// delivered, with cancelled and failed as terminal side-exits.
+package orderstate
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Status is the lifecycle state of an order.
+type Status string
+
+const (
+	Pending   Status = "pending"
+	Accepted  Status = "accepted"
+	Cooking   Status = "cooking"
+	Ready     Status = "ready"
+	Delivered Status = "delivered"
+	Cancelled Status = "cancelled"
+	Failed    Status = "failed"
+)
+
+// ErrIllegalTransition is returned when a transition is not legal from the
+// order's current status.
+var ErrIllegalTransition = errors.New("illegal order state transition")
+
+// legalNextStates maps each status to the set of statuses it may move to.
+var legalNextStates = map[Status][]Status{
+	Pending:   {Accepted, Cancelled, Failed},
+	Accepted:  {Cooking, Cancelled, Failed},
+	Cooking:   {Ready, Cancelled, Failed},
+	Ready:     {Delivered, Cancelled, Failed},
+	Delivered: {},
+	Cancelled: {},
+	Failed:    {},
+}
+
+func legal(from, to Status) bool {
+	for _, s := range legalNextStates[from] {
+		if s == to {
+			return true
+		}
+	}
+	return false
+}
+
+// Entry is a single audit record of a transition.
+type Entry struct {
+	From   Status    `json:"from"`
+	To     Status    `json:"to"`
+	At     time.Time `json:"at"`
+	Actor  string    `json:"actor"`
+	Reason string    `json:"reason,omitempty"`
+}
+
+// Machine tracks the current status of an order and its transition history.
+// It is held by pasta.Order and pizza.Order rather than exported raw
+// status strings so that every transition goes through Transition and gets
+// validated and audited. A Machine is shared by the order store and the API
+// handlers that read and transition the same order concurrently, so every
+// access goes through mu.
+type Machine struct {
+	mu      sync.RWMutex
+	status  Status
+	history []Entry
+}
+
+// NewMachine returns a Machine starting in the Pending status.
+func NewMachine() *Machine {
+	return &Machine{status: Pending}
+}
+
+// Status returns the current status.
+func (m *Machine) Status() Status {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.status
+}
+
+// History returns the audit trail of transitions in the order they occurred.
+func (m *Machine) History() []Entry {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	out := make([]Entry, len(m.history))
+	copy(out, m.history)
+	return out
+}
+
+// Transition moves the machine to the given status, recording an audit
+// entry, and returns the status it moved from. It returns
+// ErrIllegalTransition if the move is not permitted from the current status.
+func (m *Machine) Transition(to Status, actor, reason string) (Status, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	from := m.status
+	if !legal(from, to) {
+		return from, fmt.Errorf("%w: cannot go from %s to %s", ErrIllegalTransition, from, to)
+	}
+	m.history = append(m.history, Entry{
+		From:   from,
+		To:     to,
+		At:     time.Now(),
+		Actor:  actor,
+		Reason: reason,
+	})
+	m.status = to
+	return from, nil
+}
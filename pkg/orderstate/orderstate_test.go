@@ -0,0 +1,105 @@
+package orderstate
+
+import (
+	"errors"
+	"sync"
+	"testing"
+)
+
+func TestNewMachineStartsPending(t *testing.T) {
+	m := NewMachine()
+	if m.Status() != Pending {
+		t.Errorf("expected initial status %q, got %q", Pending, m.Status())
+	}
+	if len(m.History()) != 0 {
+		t.Errorf("expected empty history, got %d entries", len(m.History()))
+	}
+}
+
+func TestTransitionHappyPath(t *testing.T) {
+	m := NewMachine()
+	steps := []Status{Accepted, Cooking, Ready, Delivered}
+	for _, to := range steps {
+		if _, err := m.Transition(to, "kitchen", ""); err != nil {
+			t.Fatalf("Transition(%s) returned unexpected error: %v", to, err)
+		}
+	}
+	if m.Status() != Delivered {
+		t.Errorf("expected final status %q, got %q", Delivered, m.Status())
+	}
+	if len(m.History()) != len(steps) {
+		t.Errorf("expected %d history entries, got %d", len(steps), len(m.History()))
+	}
+}
+
+func TestTransitionRejectsIllegalMoves(t *testing.T) {
+	m := NewMachine()
+	if _, err := m.Transition(Ready, "kitchen", ""); err == nil {
+		t.Error("expected error transitioning straight from pending to ready, got nil")
+	} else if !errors.Is(err, ErrIllegalTransition) {
+		t.Errorf("expected ErrIllegalTransition, got %v", err)
+	}
+}
+
+func TestTransitionFromTerminalStateFails(t *testing.T) {
+	m := NewMachine()
+	if _, err := m.Transition(Cancelled, "customer", "changed my mind"); err != nil {
+		t.Fatalf("unexpected error cancelling pending order: %v", err)
+	}
+	if _, err := m.Transition(Accepted, "kitchen", ""); err == nil {
+		t.Error("expected error transitioning out of a terminal state, got nil")
+	}
+}
+
+func TestHistoryRecordsActorAndReason(t *testing.T) {
+	m := NewMachine()
+	if _, err := m.Transition(Cancelled, "customer", "out of stock"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	history := m.History()
+	if len(history) != 1 {
+		t.Fatalf("expected 1 history entry, got %d", len(history))
+	}
+	entry := history[0]
+	if entry.From != Pending || entry.To != Cancelled {
+		t.Errorf("expected transition pending->cancelled, got %s->%s", entry.From, entry.To)
+	}
+	if entry.Actor != "customer" || entry.Reason != "out of stock" {
+		t.Errorf("expected actor/reason to be recorded, got actor=%q reason=%q", entry.Actor, entry.Reason)
+	}
+}
+
+// TestConcurrentTransitionAndReadsDoNotRace mirrors api.TransitionOrder and
+// api.GetOrder being called concurrently against the same order held in the
+// shared order store: one goroutine drives the machine through legal
+// transitions while others read Status and History throughout. Run with
+// `go test -race` to confirm Machine's internal locking holds up.
+func TestConcurrentTransitionAndReadsDoNotRace(t *testing.T) {
+	m := NewMachine()
+
+	var wg sync.WaitGroup
+	wg.Add(3)
+
+	go func() {
+		defer wg.Done()
+		for _, to := range []Status{Accepted, Cooking, Ready, Delivered} {
+			if _, err := m.Transition(to, "kitchen", ""); err != nil {
+				t.Errorf("unexpected error transitioning to %s: %v", to, err)
+			}
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 1000; i++ {
+			_ = m.Status()
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 1000; i++ {
+			_ = m.History()
+		}
+	}()
+
+	wg.Wait()
+}
@@ -2,9 +2,19 @@ package pasta
 
 import (
 	"errors"
+	"fmt"
 	"time"
+
+	"github.com/yourusername/pasta-factory/pkg/events"
+	"github.com/yourusername/pasta-factory/pkg/menu"
+	"github.com/yourusername/pasta-factory/pkg/metrics"
+	"github.com/yourusername/pasta-factory/pkg/orderid"
+	"github.com/yourusername/pasta-factory/pkg/orderstate"
 )
 
+// orderKind identifies pasta orders on the event bus.
+const orderKind = "pasta"
+
 type PastaType string
 
 const (
@@ -20,48 +30,136 @@ type Pasta struct {
 }
 
 type Order struct {
-	ID          string
-	PastaType   PastaType
-	WeightGrams int
-	Status      string
-	CreatedAt   time.Time
+	ID            string
+	PastaType     PastaType
+	WeightGrams   int
+	CreatedAt     time.Time
+	ClientOrderID string
+
+	state *orderstate.Machine
 }
 
 func NewOrder(pastaType PastaType, weightGrams int) (*Order, error) {
-	if weightGrams < 100 {
-		return nil, errors.New("minimum order is 100 grams")
+	item, ok := menu.Default().Current().Lookup(orderKind, string(pastaType))
+	if !ok {
+		metrics.RecordOrderFailed(orderKind, "invalid_type")
+		return nil, errors.New("invalid pasta type")
 	}
 
-	if weightGrams > 5000 {
-		return nil, errors.New("maximum order is 5000 grams")
+	if !item.AvailableAt(time.Now()) {
+		metrics.RecordOrderFailed(orderKind, "unavailable")
+		return nil, fmt.Errorf("%s is not available right now", pastaType)
 	}
 
-	switch pastaType {
-	case Spaghetti, Penne, Fettuccine:
-		// Valid pasta types
-	default:
-		return nil, errors.New("invalid pasta type")
+	if weightGrams < item.Min {
+		metrics.RecordOrderFailed(orderKind, "weight_too_low")
+		return nil, fmt.Errorf("minimum order is %d grams", item.Min)
+	}
+
+	if weightGrams > item.Max {
+		metrics.RecordOrderFailed(orderKind, "weight_too_high")
+		return nil, fmt.Errorf("maximum order is %d grams", item.Max)
 	}
 
-	return &Order{
-		ID:          generateOrderID(),
+	order := &Order{
+		ID:          orderid.New(),
 		PastaType:   pastaType,
 		WeightGrams: weightGrams,
-		Status:      "pending",
 		CreatedAt:   time.Now(),
-	}, nil
+		state:       orderstate.NewMachine(),
+	}
+
+	events.DefaultBus.Publish(events.Event{
+		Type:      events.OrderCreated,
+		OrderID:   order.ID,
+		OrderKind: orderKind,
+		Status:    string(order.Status()),
+		At:        order.CreatedAt,
+	})
+	events.DefaultBus.Publish(events.Event{
+		Type:      events.OrderPriced,
+		OrderID:   order.ID,
+		OrderKind: orderKind,
+		Price:     order.CalculatePrice(),
+		At:        order.CreatedAt,
+	})
+	metrics.RecordOrderCreated(orderKind, string(pastaType), order.CalculatePrice())
+
+	return order, nil
+}
+
+// Status returns the order's current lifecycle status.
+func (o *Order) Status() orderstate.Status {
+	return o.state.Status()
+}
+
+// History returns the order's audit trail of lifecycle transitions.
+func (o *Order) History() []orderstate.Entry {
+	return o.state.History()
 }
 
-func generateOrderID() string {
-	return time.Now().Format("20060102150405")
+// Accept moves the order from pending to accepted.
+func (o *Order) Accept(actor string) error {
+	return o.transition(orderstate.Accepted, actor, "")
+}
+
+// StartCooking moves the order from accepted to cooking.
+func (o *Order) StartCooking(actor string) error {
+	return o.transition(orderstate.Cooking, actor, "")
+}
+
+// MarkReady moves the order from cooking to ready.
+func (o *Order) MarkReady(actor string) error {
+	return o.transition(orderstate.Ready, actor, "")
+}
+
+// Deliver moves the order from ready to delivered.
+func (o *Order) Deliver(actor string) error {
+	return o.transition(orderstate.Delivered, actor, "")
+}
+
+// Cancel moves the order to cancelled, recording why it was cancelled.
+func (o *Order) Cancel(actor, reason string) error {
+	return o.transition(orderstate.Cancelled, actor, reason)
+}
+
+// Fail moves the order to failed, recording why it failed.
+func (o *Order) Fail(actor, reason string) error {
+	return o.transition(orderstate.Failed, actor, reason)
+}
+
+// transition drives the order's state machine and, on success, publishes
+// the resulting change on the event bus so kitchen displays and dashboards
+// learn about it without polling.
+func (o *Order) transition(to orderstate.Status, actor, reason string) error {
+	from, err := o.state.Transition(to, actor, reason)
+	if err != nil {
+		return err
+	}
+
+	evtType := events.OrderStateChanged
+	if to == orderstate.Cancelled {
+		evtType = events.OrderCancelled
+	}
+	events.DefaultBus.Publish(events.Event{
+		Type:      evtType,
+		OrderID:   o.ID,
+		OrderKind: orderKind,
+		Status:    string(to),
+		From:      string(from),
+		Reason:    reason,
+		At:        time.Now(),
+	})
+	return nil
 }
 
+// CalculatePrice prices the order from the menu's unit price and premium
+// multiplier for its pasta type. It returns 0 if the pasta type has since
+// been removed from the menu.
 func (o *Order) CalculatePrice() float64 {
-	basePrice := float64(o.WeightGrams) * 0.01 // 1 cent per gram
-	switch o.PastaType {
-	case Fettuccine:
-		return basePrice * 1.2 // 20% premium for fancy pasta
-	default:
-		return basePrice
+	item, ok := menu.Default().Current().Lookup(orderKind, string(o.PastaType))
+	if !ok {
+		return 0
 	}
+	return float64(o.WeightGrams) * item.UnitPrice * item.PremiumMultiplier
 } 
\ No newline at end of file
@@ -3,6 +3,8 @@ package pasta
 import (
     "testing"
     "time"
+
+    "github.com/yourusername/pasta-factory/pkg/events"
 )
 
 func TestNewOrder(t *testing.T) {
@@ -106,12 +108,12 @@ func TestOrderBoundaryValues(t *testing.T) {
         t.Errorf("Expected price: %v, got: %v", expectedPrice, priceHigh)
     }
 
-    // Check that the generated order IDs follow the expected 14-digit timestamp format.
-    if len(orderLow.ID) != 14 {
-        t.Errorf("Expected orderLow ID to be 14 characters long, got: %s", orderLow.ID)
+    // Check that the generated order IDs follow the orderid package's 26-character format.
+    if len(orderLow.ID) != 26 {
+        t.Errorf("Expected orderLow ID to be 26 characters long, got: %s", orderLow.ID)
     }
-    if len(orderHigh.ID) != 14 {
-        t.Errorf("Expected orderHigh ID to be 14 characters long, got: %s", orderHigh.ID)
+    if len(orderHigh.ID) != 26 {
+        t.Errorf("Expected orderHigh ID to be 26 characters long, got: %s", orderHigh.ID)
     }
 }
 
@@ -122,8 +124,8 @@ func TestOrderCreationFields(t *testing.T) {
     }
 
     // Verify that the order status is set to "pending"
-    if order.Status != "pending" {
-        t.Errorf("expected order status 'pending', got '%s'", order.Status)
+    if order.Status() != "pending" {
+        t.Errorf("expected order status 'pending', got '%s'", order.Status())
     }
 
     // Verify that CreatedAt is properly initialized (non-zero and recent)
@@ -136,25 +138,91 @@ func TestOrderCreationFields(t *testing.T) {
         t.Error("order CreatedAt timestamp is not recent")
     }
 
-    // Verify that the order ID has the expected 14 character format (timestamp)
-    if len(order.ID) != 14 {
-        t.Errorf("expected order ID of length 14, got %d", len(order.ID))
+    // Verify that the order ID has the expected 26-character orderid format.
+    if len(order.ID) != 26 {
+        t.Errorf("expected order ID of length 26, got %d", len(order.ID))
     }
 }
-// TestUniqueOrderIDs verifies that sequential orders generate unique IDs by ensuring that
-// the timestamp-based order ID from generateOrderID is different between two orders created at different times.
+
+// TestUniqueOrderIDs verifies that orders created back-to-back, with no
+// delay between them, still get distinct IDs. orderid.New mixes in random
+// entropy precisely so this no longer depends on the wall clock ticking
+// over to a new second.
 func TestUniqueOrderIDs(t *testing.T) {
-    order1, err := NewOrder(Spaghetti, 500)
+    const n = 1000
+    seen := make(map[string]bool, n)
+    for i := 0; i < n; i++ {
+        order, err := NewOrder(Spaghetti, 500)
+        if err != nil {
+            t.Fatalf("unexpected error creating order: %v", err)
+        }
+        if seen[order.ID] {
+            t.Fatalf("duplicate order ID generated: %s", order.ID)
+        }
+        seen[order.ID] = true
+    }
+}
+
+func TestAcceptTransitionsToAccepted(t *testing.T) {
+    order, err := NewOrder(Spaghetti, 500)
     if err != nil {
-        t.Fatalf("unexpected error creating order1: %v", err)
+        t.Fatalf("unexpected error creating order: %v", err)
+    }
+    if err := order.Accept("kitchen"); err != nil {
+        t.Fatalf("unexpected error accepting order: %v", err)
+    }
+    if order.Status() != "accepted" {
+        t.Errorf("expected status 'accepted', got %q", order.Status())
     }
-    // Sleep for slightly more than 1 second to guarantee a different timestamp for the next order.
-    time.Sleep(1100 * time.Millisecond)
-    order2, err := NewOrder(Penne, 500)
+    if len(order.History()) != 1 {
+        t.Fatalf("expected 1 history entry, got %d", len(order.History()))
+    }
+}
+
+func TestDeliverBeforeAcceptIsIllegal(t *testing.T) {
+    order, err := NewOrder(Spaghetti, 500)
     if err != nil {
-        t.Fatalf("unexpected error creating order2: %v", err)
+        t.Fatalf("unexpected error creating order: %v", err)
     }
-    if order1.ID == order2.ID {
-        t.Errorf("expected unique order IDs, but got the same ID: %s", order1.ID)
+    if err := order.Deliver("kitchen"); err == nil {
+        t.Error("expected an error delivering an order that was never accepted, got nil")
+    }
+}
+
+func TestNewOrderPublishesCreatedAndPricedEvents(t *testing.T) {
+    ch := events.DefaultBus.Subscribe(events.Filter{Kind: "pasta"})
+    defer events.DefaultBus.Unsubscribe(ch)
+
+    order, err := NewOrder(Fettuccine, 1000)
+    if err != nil {
+        t.Fatalf("unexpected error creating order: %v", err)
+    }
+
+    created := <-ch
+    if created.Type != events.OrderCreated || created.OrderID != order.ID {
+        t.Errorf("expected an OrderCreated event for %q, got %+v", order.ID, created)
+    }
+
+    priced := <-ch
+    if priced.Type != events.OrderPriced || priced.Price != order.CalculatePrice() {
+        t.Errorf("expected an OrderPriced event with price %v, got %+v", order.CalculatePrice(), priced)
+    }
+}
+
+func TestCancelPublishesOrderCancelledEvent(t *testing.T) {
+    ch := events.DefaultBus.Subscribe(events.Filter{Kind: "pasta", Status: "cancelled"})
+    defer events.DefaultBus.Unsubscribe(ch)
+
+    order, err := NewOrder(Spaghetti, 500)
+    if err != nil {
+        t.Fatalf("unexpected error creating order: %v", err)
+    }
+    if err := order.Cancel("customer", "changed my mind"); err != nil {
+        t.Fatalf("unexpected error cancelling order: %v", err)
+    }
+
+    evt := <-ch
+    if evt.Type != events.OrderCancelled || evt.Reason != "changed my mind" {
+        t.Errorf("expected an OrderCancelled event with the cancellation reason, got %+v", evt)
     }
 }
\ No newline at end of file
@@ -3,9 +3,19 @@ package pizza
 
 import (
 	"errors"
+	"fmt"
 	"time"
+
+	"github.com/yourusername/pasta-factory/pkg/events"
+	"github.com/yourusername/pasta-factory/pkg/menu"
+	"github.com/yourusername/pasta-factory/pkg/metrics"
+	"github.com/yourusername/pasta-factory/pkg/orderid"
+	"github.com/yourusername/pasta-factory/pkg/orderstate"
 )
 
+// orderKind identifies pizza orders on the event bus.
+const orderKind = "pizza"
+
 type PizzaType string
 
 const (
@@ -21,62 +31,141 @@ type Pizza struct {
 }
 
 type Order struct {
-	ID        string
-	PizzaType PizzaType
-	SizeInch  int
-	Status    string
-	CreatedAt time.Time
+	ID            string
+	PizzaType     PizzaType
+	SizeInch      int
+	CreatedAt     time.Time
+	ClientOrderID string
+
+	state *orderstate.Machine
 }
 
 // NewOrder creates a new pizza order with the specified type and size.
 // It returns an error if the size is outside the valid range (8-24 inches)
 // or if the pizza type is invalid.
 func NewOrder(pizzaType PizzaType, sizeInch int) (*Order, error) {
-	if sizeInch < 8 {
-		return nil, errors.New("minimum size is 8 inches")
+	item, ok := menu.Default().Current().Lookup(orderKind, string(pizzaType))
+	if !ok {
+		metrics.RecordOrderFailed(orderKind, "invalid_type")
+		return nil, errors.New("invalid pizza type")
 	}
 
-	if sizeInch > 24 {
-		return nil, errors.New("maximum size is 24 inches")
+	if !item.AvailableAt(time.Now()) {
+		metrics.RecordOrderFailed(orderKind, "unavailable")
+		return nil, fmt.Errorf("%s is not available right now", pizzaType)
 	}
 
-	switch pizzaType {
-	case Margherita, Pepperoni:
-		// Valid pizza types
-	default:
-		return nil, errors.New("invalid pizza type")
+	if sizeInch < item.Min {
+		metrics.RecordOrderFailed(orderKind, "size_too_small")
+		return nil, fmt.Errorf("minimum size is %d inches", item.Min)
+	}
+
+	if sizeInch > item.Max {
+		metrics.RecordOrderFailed(orderKind, "size_too_large")
+		return nil, fmt.Errorf("maximum size is %d inches", item.Max)
 	}
 
-	return &Order{
-		ID:        generateOrderID(),
+	order := &Order{
+		ID:        orderid.New(),
 		PizzaType: pizzaType,
 		SizeInch:  sizeInch,
-		Status:    "pending",
 		CreatedAt: time.Now(),
-	}, nil
+		state:     orderstate.NewMachine(),
+	}
+
+	events.DefaultBus.Publish(events.Event{
+		Type:      events.OrderCreated,
+		OrderID:   order.ID,
+		OrderKind: orderKind,
+		Status:    string(order.Status()),
+		At:        order.CreatedAt,
+	})
+	events.DefaultBus.Publish(events.Event{
+		Type:      events.OrderPriced,
+		OrderID:   order.ID,
+		OrderKind: orderKind,
+		Price:     order.CalculatePrice(),
+		At:        order.CreatedAt,
+	})
+	metrics.RecordOrderCreated(orderKind, string(pizzaType), order.CalculatePrice())
+
+	return order, nil
+}
+
+// Status returns the order's current lifecycle status.
+func (o *Order) Status() orderstate.Status {
+	return o.state.Status()
 }
 
-// generateOrderID creates a unique order ID based on the current timestamp
-// in the format "YYYYMMDDhhmmss".
-func generateOrderID() string {
-	return time.Now().Format("20060102150405")
+// History returns the order's audit trail of lifecycle transitions.
+func (o *Order) History() []orderstate.Entry {
+	return o.state.History()
+}
+
+// Accept moves the order from pending to accepted.
+func (o *Order) Accept(actor string) error {
+	return o.transition(orderstate.Accepted, actor, "")
+}
+
+// StartCooking moves the order from accepted to cooking.
+func (o *Order) StartCooking(actor string) error {
+	return o.transition(orderstate.Cooking, actor, "")
+}
+
+// MarkReady moves the order from cooking to ready.
+func (o *Order) MarkReady(actor string) error {
+	return o.transition(orderstate.Ready, actor, "")
+}
+
+// Deliver moves the order from ready to delivered.
+func (o *Order) Deliver(actor string) error {
+	return o.transition(orderstate.Delivered, actor, "")
+}
+
+// Cancel moves the order to cancelled, recording why it was cancelled.
+func (o *Order) Cancel(actor, reason string) error {
+	return o.transition(orderstate.Cancelled, actor, reason)
+}
+
+// Fail moves the order to failed, recording why it failed.
+func (o *Order) Fail(actor, reason string) error {
+	return o.transition(orderstate.Failed, actor, reason)
+}
+
+// transition drives the order's state machine and, on success, publishes
+// the resulting change on the event bus so kitchen displays and dashboards
+// learn about it without polling.
+func (o *Order) transition(to orderstate.Status, actor, reason string) error {
+	from, err := o.state.Transition(to, actor, reason)
+	if err != nil {
+		return err
+	}
+
+	evtType := events.OrderStateChanged
+	if to == orderstate.Cancelled {
+		evtType = events.OrderCancelled
+	}
+	events.DefaultBus.Publish(events.Event{
+		Type:      evtType,
+		OrderID:   o.ID,
+		OrderKind: orderKind,
+		Status:    string(to),
+		From:      string(from),
+		Reason:    reason,
+		At:        time.Now(),
+	})
+	return nil
 }
 
-// CalculatePrice determines the price of the pizza based on its size and type.
-// The base price is $1 per inch of diameter with premiums applied for specialty types:
-// - Pepperoni: 20% premium
-// - Hawaiian: 30% premium
-// - Margherita: no premium (base price)
+// CalculatePrice prices the order from the menu's unit price and premium
+// multiplier for its pizza type. It returns 0 if the pizza type has since
+// been removed from the menu.
 func (o *Order) CalculatePrice() float64 {
-	basePrice := float64(o.SizeInch) * 1.0 // $1 per inch of diameter
-	switch o.PizzaType {
-	case Pepperoni:
-		return basePrice * 1.2 // 20% premium for pepperoni
-	case Hawaiian:
-		return basePrice * 1.3 // 30% premium for hawaiian
-	default:
-		return basePrice
+	item, ok := menu.Default().Current().Lookup(orderKind, string(o.PizzaType))
+	if !ok {
+		return 0
 	}
+	return float64(o.SizeInch) * item.UnitPrice * item.PremiumMultiplier
 }
 
 // CalculateCringeLevel determines the cringe level of an array of orders based on the pizzas size and types.
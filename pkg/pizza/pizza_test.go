@@ -0,0 +1,238 @@
+package pizza
+
+import (
+	"testing"
+	"time"
+
+	"github.com/yourusername/pasta-factory/pkg/events"
+)
+
+func TestNewOrder(t *testing.T) {
+	tests := []struct {
+		name     string
+		pizzaType PizzaType
+		sizeInch int
+		wantErr  bool
+	}{
+		{
+			name:     "valid order",
+			pizzaType: Margherita,
+			sizeInch: 12,
+			wantErr:  false,
+		},
+		{
+			name:     "too small order",
+			pizzaType: Pepperoni,
+			sizeInch: 4,
+			wantErr:  true,
+		},
+		{
+			name:     "too large order",
+			pizzaType: Margherita,
+			sizeInch: 30,
+			wantErr:  true,
+		},
+		{
+			name:     "invalid pizza type",
+			pizzaType: "calzone",
+			sizeInch: 12,
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			order, err := NewOrder(tt.pizzaType, tt.sizeInch)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewOrder() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && order == nil {
+				t.Error("NewOrder() returned nil order when no error expected")
+			}
+		})
+	}
+}
+
+func TestCalculatePrice(t *testing.T) {
+	// Standard pizza (Margherita) is priced at $1 per inch of diameter, while
+	// a premium pizza (Pepperoni) receives a 20% price increase.
+	orderStandard, err := NewOrder(Margherita, 12)
+	if err != nil {
+		t.Fatalf("unexpected error creating standard order: %v", err)
+	}
+	priceStandard := orderStandard.CalculatePrice()
+	expectedStandard := 12 * 1.0
+	if priceStandard != expectedStandard {
+		t.Errorf("CalculatePrice() for standard pizza: got %v, want %v", priceStandard, expectedStandard)
+	}
+
+	orderPremium, err := NewOrder(Pepperoni, 12)
+	if err != nil {
+		t.Fatalf("unexpected error creating premium order: %v", err)
+	}
+	pricePremium := orderPremium.CalculatePrice()
+	expectedPremium := float64(12) * 1.0 * 1.2
+	if pricePremium != expectedPremium {
+		t.Errorf("CalculatePrice() for premium pizza: got %v, want %v", pricePremium, expectedPremium)
+	}
+}
+
+func TestOrderBoundaryValues(t *testing.T) {
+	orderLow, err := NewOrder(Margherita, 8)
+	if err != nil {
+		t.Errorf("Expected no error for an 8 inch order, got: %v", err)
+	}
+	if orderLow.SizeInch != 8 {
+		t.Errorf("Expected order size to be 8, got: %d", orderLow.SizeInch)
+	}
+
+	orderHigh, err := NewOrder(Pepperoni, 24)
+	if err != nil {
+		t.Errorf("Expected no error for a 24 inch order, got: %v", err)
+	}
+	if orderHigh.SizeInch != 24 {
+		t.Errorf("Expected order size to be 24, got: %d", orderHigh.SizeInch)
+	}
+
+	priceHigh := orderHigh.CalculatePrice()
+	expectedPrice := float64(24) * 1.0 * 1.2
+	if priceHigh != expectedPrice {
+		t.Errorf("Expected price: %v, got: %v", expectedPrice, priceHigh)
+	}
+
+	if len(orderLow.ID) != 26 {
+		t.Errorf("Expected orderLow ID to be 26 characters long, got: %s", orderLow.ID)
+	}
+	if len(orderHigh.ID) != 26 {
+		t.Errorf("Expected orderHigh ID to be 26 characters long, got: %s", orderHigh.ID)
+	}
+}
+
+func TestOrderCreationFields(t *testing.T) {
+	order, err := NewOrder(Margherita, 12)
+	if err != nil {
+		t.Fatalf("unexpected error creating order: %v", err)
+	}
+
+	if order.Status() != "pending" {
+		t.Errorf("expected order status 'pending', got '%s'", order.Status())
+	}
+
+	if order.CreatedAt.IsZero() {
+		t.Error("expected CreatedAt to be initialized, but it was zero")
+	}
+
+	now := time.Now()
+	if now.Sub(order.CreatedAt) > 2*time.Second {
+		t.Error("order CreatedAt timestamp is not recent")
+	}
+
+	if len(order.ID) != 26 {
+		t.Errorf("expected order ID of length 26, got %d", len(order.ID))
+	}
+}
+
+// TestUniqueOrderIDs verifies that orders created back-to-back, with no
+// delay between them, still get distinct IDs.
+func TestUniqueOrderIDs(t *testing.T) {
+	const n = 1000
+	seen := make(map[string]bool, n)
+	for i := 0; i < n; i++ {
+		order, err := NewOrder(Margherita, 12)
+		if err != nil {
+			t.Fatalf("unexpected error creating order: %v", err)
+		}
+		if seen[order.ID] {
+			t.Fatalf("duplicate order ID generated: %s", order.ID)
+		}
+		seen[order.ID] = true
+	}
+}
+
+func TestAcceptTransitionsToAccepted(t *testing.T) {
+	order, err := NewOrder(Margherita, 12)
+	if err != nil {
+		t.Fatalf("unexpected error creating order: %v", err)
+	}
+	if err := order.Accept("kitchen"); err != nil {
+		t.Fatalf("unexpected error accepting order: %v", err)
+	}
+	if order.Status() != "accepted" {
+		t.Errorf("expected status 'accepted', got %q", order.Status())
+	}
+	if len(order.History()) != 1 {
+		t.Fatalf("expected 1 history entry, got %d", len(order.History()))
+	}
+}
+
+func TestDeliverBeforeAcceptIsIllegal(t *testing.T) {
+	order, err := NewOrder(Margherita, 12)
+	if err != nil {
+		t.Fatalf("unexpected error creating order: %v", err)
+	}
+	if err := order.Deliver("kitchen"); err == nil {
+		t.Error("expected an error delivering an order that was never accepted, got nil")
+	}
+}
+
+func TestNewOrderPublishesCreatedAndPricedEvents(t *testing.T) {
+	ch := events.DefaultBus.Subscribe(events.Filter{Kind: "pizza"})
+	defer events.DefaultBus.Unsubscribe(ch)
+
+	order, err := NewOrder(Pepperoni, 12)
+	if err != nil {
+		t.Fatalf("unexpected error creating order: %v", err)
+	}
+
+	created := <-ch
+	if created.Type != events.OrderCreated || created.OrderID != order.ID {
+		t.Errorf("expected an OrderCreated event for %q, got %+v", order.ID, created)
+	}
+
+	priced := <-ch
+	if priced.Type != events.OrderPriced || priced.Price != order.CalculatePrice() {
+		t.Errorf("expected an OrderPriced event with price %v, got %+v", order.CalculatePrice(), priced)
+	}
+}
+
+func TestCancelPublishesOrderCancelledEvent(t *testing.T) {
+	ch := events.DefaultBus.Subscribe(events.Filter{Kind: "pizza", Status: "cancelled"})
+	defer events.DefaultBus.Unsubscribe(ch)
+
+	order, err := NewOrder(Margherita, 12)
+	if err != nil {
+		t.Fatalf("unexpected error creating order: %v", err)
+	}
+	if err := order.Cancel("customer", "changed my mind"); err != nil {
+		t.Fatalf("unexpected error cancelling order: %v", err)
+	}
+
+	evt := <-ch
+	if evt.Type != events.OrderCancelled || evt.Reason != "changed my mind" {
+		t.Errorf("expected an OrderCancelled event with the cancellation reason, got %+v", evt)
+	}
+}
+
+// TestCalculateCringeLevel builds Orders directly rather than through
+// NewOrder, since Hawaiian is only available in the menu during an evening
+// window and cringe level shouldn't depend on what time the tests run.
+func TestCalculateCringeLevel(t *testing.T) {
+	tests := []struct {
+		name     string
+		order    Order
+		expected int
+	}{
+		{name: "margherita is never cringe", order: Order{PizzaType: Margherita, SizeInch: 16}, expected: 0},
+		{name: "pepperoni is never cringe", order: Order{PizzaType: Pepperoni, SizeInch: 16}, expected: 0},
+		{name: "hawaiian cringe grows with size", order: Order{PizzaType: Hawaiian, SizeInch: 16}, expected: 16},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.order.CalculateCringeLevel(); got != tt.expected {
+				t.Errorf("CalculateCringeLevel() = %d, want %d", got, tt.expected)
+			}
+		})
+	}
+}